@@ -0,0 +1,179 @@
+package config
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrCircuitOpen dikembalikan ketika circuit breaker sedang open sehingga
+// request tidak dikirim ke service tujuan.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState adalah status circuit breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed: request berjalan normal
+	BreakerClosed BreakerState = iota
+	// BreakerOpen: request langsung ditolak sampai cooldown selesai
+	BreakerOpen
+	// BreakerHalfOpen: satu request percobaan diizinkan untuk mengecek service tujuan
+	BreakerHalfOpen
+)
+
+// String mengembalikan nama status untuk keperluan logging dan monitoring.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker menghentikan sementara request ke service yang sedang gagal.
+// Breaker menjadi open setelah threshold kegagalan berturut-turut, lalu
+// setelah cooldown mengizinkan satu request percobaan (half-open).
+type CircuitBreaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+
+	mutex    sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+var (
+	breakersMutex sync.RWMutex
+	breakers      = map[string]*CircuitBreaker{}
+)
+
+// NewCircuitBreaker membuat circuit breaker baru dan mendaftarkannya dengan
+// nama tertentu agar statusnya bisa dibaca lewat BreakerStates.
+//
+// Parameters:
+//   - name: nama breaker, biasanya nama service tujuan
+//   - threshold: jumlah kegagalan berturut-turut sebelum breaker open
+//   - cooldown: lama breaker open sebelum mencoba lagi
+func NewCircuitBreaker(name string, threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = 1
+	}
+
+	breaker := &CircuitBreaker{
+		name:      name,
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+
+	breakersMutex.Lock()
+	breakers[name] = breaker
+	breakersMutex.Unlock()
+
+	return breaker
+}
+
+// BreakerStates mengembalikan status semua circuit breaker yang terdaftar
+// berdasarkan nama, digunakan untuk monitoring.
+func BreakerStates() map[string]BreakerState {
+	breakersMutex.RLock()
+	defer breakersMutex.RUnlock()
+
+	states := make(map[string]BreakerState, len(breakers))
+	for name, breaker := range breakers {
+		states[name] = breaker.State()
+	}
+	return states
+}
+
+// Name mengembalikan nama breaker.
+func (b *CircuitBreaker) Name() string {
+	return b.name
+}
+
+// State mengembalikan status breaker saat ini.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.currentState()
+}
+
+// currentState menghitung status dengan memperhitungkan cooldown yang sudah
+// lewat. Harus dipanggil saat mutex sudah di-lock.
+func (b *CircuitBreaker) currentState() BreakerState {
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.cooldown {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// Allow mengecek apakah request boleh dikirim. Saat half-open hanya satu
+// request percobaan yang diizinkan sampai hasilnya dilaporkan.
+func (b *CircuitBreaker) Allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.currentState() {
+	case BreakerOpen:
+		return ErrCircuitOpen
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+	}
+	return nil
+}
+
+// Success melaporkan request yang berhasil dan menutup kembali breaker.
+func (b *CircuitBreaker) Success() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.failures = 0
+	b.probing = false
+	b.setState(BreakerClosed)
+}
+
+// Release melepas izin request percobaan tanpa mengubah status, dipakai
+// ketika request dibatalkan oleh pemanggil sebelum hasilnya diketahui.
+func (b *CircuitBreaker) Release() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.probing = false
+}
+
+// Failure melaporkan request yang gagal. Breaker menjadi open jika threshold
+// tercapai atau jika request percobaan saat half-open gagal.
+func (b *CircuitBreaker) Failure() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.probing = false
+		b.openedAt = time.Now()
+		b.setState(BreakerOpen)
+	}
+}
+
+// setState mengganti status dan mencatat perubahan ke log.
+func (b *CircuitBreaker) setState(state BreakerState) {
+	if b.state == state {
+		return
+	}
+
+	logrus.Warnf("circuit breaker %s changed from %s to %s", b.name, b.state, state)
+	b.state = state
+}
@@ -3,17 +3,32 @@
 // pattern dependency injection dan functional options untuk fleksibilitas.
 package config
 
-import "github.com/parnurzeal/gorequest"
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/parnurzeal/gorequest"
+)
 
 // ClientConfig adalah struktur yang menyimpan konfigurasi untuk HTTP client.
 // Struktur ini menggunakan gorequest sebagai underlying HTTP client library.
 type ClientConfig struct {
 	// client adalah instance dari gorequest.SuperAgent yang digunakan untuk melakukan HTTP request
 	client *gorequest.SuperAgent
+	// mutex melindungi baseURL dan signatureKey yang bisa diganti saat config reload
+	mutex sync.RWMutex
 	// baseURL adalah URL dasar untuk semua request yang akan dibuat
 	baseURL string
 	// signatureKey adalah kunci yang digunakan untuk autentikasi atau signing request
 	signatureKey string
+	// timeout adalah batas waktu untuk setiap percobaan request
+	timeout time.Duration
+	// retry adalah aturan pengulangan request yang gagal
+	retry RetryPolicy
+	// breaker adalah circuit breaker untuk service tujuan, nil jika tidak dipakai
+	breaker *CircuitBreaker
 }
 
 // IClientConfig adalah interface yang mendefinisikan kontrak untuk konfigurasi client.
@@ -25,6 +40,12 @@ type IClientConfig interface {
 	BaseURL() string
 	// SignatureKey mengembalikan kunci signature yang dikonfigurasi
 	SignatureKey() string
+	// SetEndpoint mengganti base URL dan signature key saat config berubah
+	SetEndpoint(baseURL, signatureKey string)
+	// Breaker mengembalikan circuit breaker yang dikonfigurasi, nil jika tidak ada
+	Breaker() *CircuitBreaker
+	// Do mengirim request dengan timeout, retry dan circuit breaker
+	Do(context.Context, *gorequest.SuperAgent) (*http.Response, []byte, error)
 }
 
 // Option adalah function type yang digunakan untuk mengkonfigurasi ClientConfig.
@@ -66,15 +87,36 @@ func (c *ClientConfig) Client() *gorequest.SuperAgent {
 // BaseURL mengembalikan URL dasar yang dikonfigurasi untuk client.
 // URL ini akan digunakan sebagai prefix untuk semua endpoint request.
 func (c *ClientConfig) BaseURL() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
 	return c.baseURL
 }
 
 // SignatureKey mengembalikan kunci signature yang dikonfigurasi.
 // Kunci ini digunakan untuk autentikasi atau signing HTTP request.
 func (c *ClientConfig) SignatureKey() string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
 	return c.signatureKey
 }
 
+// SetEndpoint mengganti base URL dan signature key secara bersamaan.
+// Method ini dipanggil ketika config di-reload sehingga request berikutnya
+// langsung memakai nilai baru tanpa restart.
+//
+// Parameters:
+//   - baseURL: URL dasar yang baru
+//   - signatureKey: kunci signature yang baru
+func (c *ClientConfig) SetEndpoint(baseURL, signatureKey string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.baseURL = baseURL
+	c.signatureKey = signatureKey
+}
+
 // WithBaseURL adalah option function untuk mengatur base URL client.
 // Function ini mengembalikan Option yang akan mengkonfigurasi baseURL.
 //
@@ -110,3 +152,71 @@ func WithSignatureKey(signatureKey string) Option {
 		c.signatureKey = signatureKey
 	}
 }
+
+// Breaker mengembalikan circuit breaker yang dikonfigurasi.
+// Nilai nil berarti client tidak menggunakan circuit breaker.
+func (c *ClientConfig) Breaker() *CircuitBreaker {
+	return c.breaker
+}
+
+// WithTimeout adalah option function untuk mengatur batas waktu setiap percobaan request.
+//
+// Parameters:
+//   - timeout: batas waktu satu percobaan, 0 berarti tanpa batas
+//
+// Returns:
+//   - Option: function yang akan mengaplikasikan konfigurasi timeout
+//
+// Example:
+//
+//	config := NewClientConfig(WithTimeout(5 * time.Second))
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *ClientConfig) {
+		c.timeout = timeout
+	}
+}
+
+// WithRetry adalah option function untuk mengulang request yang gagal karena
+// network error atau response 5xx dengan exponential backoff dan jitter.
+//
+// Parameters:
+//   - maxAttempts: jumlah percobaan total, termasuk percobaan pertama
+//   - baseDelay: jeda awal sebelum percobaan kedua
+//   - maxDelay: batas atas jeda antar percobaan
+//
+// Returns:
+//   - Option: function yang akan mengaplikasikan konfigurasi retry
+//
+// Example:
+//
+//	config := NewClientConfig(WithRetry(3, 100*time.Millisecond, 2*time.Second))
+func WithRetry(maxAttempts int, baseDelay, maxDelay time.Duration) Option {
+	return func(c *ClientConfig) {
+		c.retry = RetryPolicy{
+			MaxAttempts: maxAttempts,
+			BaseDelay:   baseDelay,
+			MaxDelay:    maxDelay,
+		}
+	}
+}
+
+// WithCircuitBreaker adalah option function untuk memasang circuit breaker.
+// Breaker menjadi open setelah threshold kegagalan berturut-turut dan menolak
+// request sampai cooldown selesai.
+//
+// Parameters:
+//   - name: nama breaker untuk monitoring, biasanya nama service tujuan
+//   - threshold: jumlah kegagalan berturut-turut sebelum breaker open
+//   - cooldown: lama breaker open sebelum request percobaan diizinkan
+//
+// Returns:
+//   - Option: function yang akan mengaplikasikan konfigurasi circuit breaker
+//
+// Example:
+//
+//	config := NewClientConfig(WithCircuitBreaker("user-service", 5, 30*time.Second))
+func WithCircuitBreaker(name string, threshold int, cooldown time.Duration) Option {
+	return func(c *ClientConfig) {
+		c.breaker = NewCircuitBreaker(name, threshold, cooldown)
+	}
+}
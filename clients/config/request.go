@@ -0,0 +1,160 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/parnurzeal/gorequest"
+)
+
+// RetryPolicy mengatur berapa kali request diulang dan jeda antar percobaan.
+// Jeda dihitung dengan exponential backoff dan full jitter.
+type RetryPolicy struct {
+	// MaxAttempts adalah jumlah percobaan total, termasuk percobaan pertama
+	MaxAttempts int
+	// BaseDelay adalah jeda awal sebelum percobaan kedua
+	BaseDelay time.Duration
+	// MaxDelay adalah batas atas jeda antar percobaan
+	MaxDelay time.Duration
+}
+
+// backoff menghitung jeda sebelum percobaan berikutnya.
+// Jeda maksimum naik dua kali lipat setiap percobaan (BaseDelay * 2^attempt)
+// dibatasi MaxDelay, lalu diambil nilai acak di antara 0 dan jeda tersebut.
+func (r RetryPolicy) backoff(attempt int) time.Duration {
+	if r.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := r.BaseDelay << attempt
+	if delay <= 0 || (r.MaxDelay > 0 && delay > r.MaxDelay) {
+		delay = r.MaxDelay
+	}
+	return rand.N(delay + 1)
+}
+
+// ServerError dikembalikan ketika service tujuan membalas dengan status 5xx
+// pada percobaan terakhir.
+type ServerError struct {
+	StatusCode int
+}
+
+func (e *ServerError) Error() string {
+	return fmt.Sprintf("server responded with status %d", e.StatusCode)
+}
+
+// Do mengirim request yang sudah disiapkan dengan gorequest sambil menerapkan
+// timeout, retry dan circuit breaker yang dikonfigurasi.
+//
+// Network error dan response 5xx akan diulang selama percobaan masih tersisa.
+// Response 4xx dikembalikan apa adanya karena mengulang tidak akan mengubah
+// hasilnya. Pembatalan ctx menghentikan request maupun jeda retry.
+//
+// Parameters:
+//   - ctx: context request, pembatalan ctx membatalkan request
+//   - request: gorequest.SuperAgent yang sudah berisi method, URL dan header
+//
+// Returns:
+//   - *http.Response: response terakhir (body sudah dibaca dan ditutup)
+//   - []byte: body dari response terakhir
+//   - error: error jika semua percobaan gagal
+func (c *ClientConfig) Do(ctx context.Context, request *gorequest.SuperAgent) (*http.Response, []byte, error) {
+	// Step 1: Tolak langsung jika circuit breaker sedang open
+	if c.breaker != nil {
+		err := c.breaker.Allow()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	maxAttempts := max(c.retry.MaxAttempts, 1)
+
+	var (
+		resp *http.Response
+		body []byte
+		err  error
+	)
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		// Step 2: Tunggu jeda backoff sebelum percobaan ulang
+		if attempt > 0 {
+			timer := time.NewTimer(c.retry.backoff(attempt - 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				c.reportResult(ctx, ctx.Err())
+				return nil, nil, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		// Step 3: Kirim request, berhenti jika berhasil atau tidak perlu diulang
+		resp, body, err = c.send(ctx, request)
+		if !isRetryable(ctx, err) {
+			break
+		}
+	}
+
+	// Step 4: Laporkan hasil akhir ke circuit breaker
+	c.reportResult(ctx, err)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, body, nil
+}
+
+// send melakukan satu percobaan request dengan timeout per percobaan.
+func (c *ClientConfig) send(ctx context.Context, request *gorequest.SuperAgent) (*http.Response, []byte, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	httpRequest, err := request.MakeRequest()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := request.Client.Do(httpRequest.WithContext(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return resp, body, &ServerError{StatusCode: resp.StatusCode}
+	}
+	return resp, body, nil
+}
+
+// isRetryable menentukan apakah error perlu dicoba ulang. Pembatalan dari
+// pemanggil tidak diulang, sedangkan timeout per percobaan tetap diulang.
+func isRetryable(ctx context.Context, err error) bool {
+	return err != nil && ctx.Err() == nil
+}
+
+// reportResult meneruskan hasil request ke circuit breaker jika ada.
+// Pembatalan oleh pemanggil tidak dihitung sebagai kegagalan service tujuan.
+func (c *ClientConfig) reportResult(ctx context.Context, err error) {
+	if c.breaker == nil {
+		return
+	}
+
+	switch {
+	case err == nil:
+		c.breaker.Success()
+	case ctx.Err() != nil:
+		c.breaker.Release()
+	default:
+		c.breaker.Failure()
+	}
+}
@@ -0,0 +1,233 @@
+package config_test
+
+import (
+	"context"
+	"errors"
+	clientConfig "field-service/clients/config"
+	clientUser "field-service/clients/user"
+	"field-service/clients/user/fake"
+	"field-service/config"
+	"field-service/constants"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const signatureKey = "secret"
+
+var fixture = &fake.Fixture{
+	Users: []fake.FixtureUser{
+		{Token: "customer-token", UserData: clientUser.UserData{Username: "customer"}},
+	},
+}
+
+// userService wraps the fake User Service so fail can answer the first
+// attempts itself. fail returns false to let the fake serve the request.
+type userService struct {
+	*httptest.Server
+	calls atomic.Int32
+}
+
+func newUserService(t *testing.T, fail func(attempt int, w http.ResponseWriter) bool) *userService {
+	t.Helper()
+
+	config.Config.AppName = "field-service"
+	service := &userService{}
+	handler := fake.NewHandler(fixture, signatureKey)
+	service.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempt := int(service.calls.Add(1))
+		if fail != nil && fail(attempt, w) {
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(service.Close)
+	return service
+}
+
+func newClient(baseURL string, options ...clientConfig.Option) clientUser.IUserClient {
+	options = append([]clientConfig.Option{
+		clientConfig.WithBaseURL(baseURL),
+		clientConfig.WithSignatureKey(signatureKey),
+		clientConfig.WithTimeout(time.Second),
+		clientConfig.WithRetry(3, time.Millisecond, 5*time.Millisecond),
+	}, options...)
+	return clientUser.NewUserClient(clientConfig.NewClientConfig(options...))
+}
+
+func withToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, constants.Token, token)
+}
+
+// failFirst answers the first n attempts with status.
+func failFirst(n, status int) func(int, http.ResponseWriter) bool {
+	return func(attempt int, w http.ResponseWriter) bool {
+		if attempt > n {
+			return false
+		}
+		w.WriteHeader(status)
+		return true
+	}
+}
+
+// dropFirst closes the connection without a response on the first n attempts.
+func dropFirst(n int) func(int, http.ResponseWriter) bool {
+	return func(attempt int, w http.ResponseWriter) bool {
+		if attempt > n {
+			return false
+		}
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			_ = conn.Close()
+		}
+		return true
+	}
+}
+
+func TestDoReturnsUserFromFakeServer(t *testing.T) {
+	config.Config.AppName = "field-service"
+	server := fake.NewServer(fixture, signatureKey)
+	defer server.Close()
+
+	user, err := newClient(server.URL).GetUserByToken(withToken(context.Background(), "customer-token"))
+	if err != nil {
+		t.Fatalf("expected user, got error %v", err)
+	}
+	if user.Username != "customer" {
+		t.Errorf("expected customer, got %q", user.Username)
+	}
+}
+
+func TestDoRetriesServerErrors(t *testing.T) {
+	service := newUserService(t, failFirst(2, http.StatusServiceUnavailable))
+
+	_, err := newClient(service.URL).GetUserByToken(withToken(context.Background(), "customer-token"))
+	if err != nil {
+		t.Fatalf("expected the third attempt to succeed, got %v", err)
+	}
+	if calls := service.calls.Load(); calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestDoRetriesNetworkErrors(t *testing.T) {
+	service := newUserService(t, dropFirst(1))
+
+	_, err := newClient(service.URL).GetUserByToken(withToken(context.Background(), "customer-token"))
+	if err != nil {
+		t.Fatalf("expected the second attempt to succeed, got %v", err)
+	}
+	if calls := service.calls.Load(); calls != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestDoGivesUpAfterMaxAttempts(t *testing.T) {
+	service := newUserService(t, failFirst(10, http.StatusBadGateway))
+
+	_, err := newClient(service.URL).GetUserByToken(withToken(context.Background(), "customer-token"))
+	var serverError *clientConfig.ServerError
+	if !errors.As(err, &serverError) || serverError.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected a 502 ServerError, got %v", err)
+	}
+	if calls := service.calls.Load(); calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestDoDoesNotRetryRejections(t *testing.T) {
+	service := newUserService(t, nil)
+
+	_, err := newClient(service.URL).GetUserByToken(withToken(context.Background(), "unknown-token"))
+	if err == nil {
+		t.Fatal("expected an unknown token to be rejected")
+	}
+	if calls := service.calls.Load(); calls != 1 {
+		t.Errorf("expected a single attempt, got %d", calls)
+	}
+}
+
+func TestDoStopsOnCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(withToken(context.Background(), "customer-token"))
+	service := newUserService(t, func(_ int, w http.ResponseWriter) bool {
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return true
+	})
+
+	client := newClient(service.URL,
+		clientConfig.WithRetry(5, 50*time.Millisecond, 50*time.Millisecond),
+		clientConfig.WithCircuitBreaker("test-cancel", 1, time.Minute),
+	)
+	_, err := client.GetUserByToken(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if calls := service.calls.Load(); calls != 1 {
+		t.Errorf("expected no retry after cancellation, got %d attempts", calls)
+	}
+	if state := clientConfig.BreakerStates()["test-cancel"]; state != clientConfig.BreakerClosed {
+		t.Errorf("expected a cancelled call not to open the breaker, got %s", state)
+	}
+}
+
+func TestDoOpensAndRecoversCircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	service := newUserService(t, func(_ int, w http.ResponseWriter) bool {
+		if healthy.Load() {
+			return false
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return true
+	})
+
+	cooldown := 50 * time.Millisecond
+	client := newClient(service.URL,
+		clientConfig.WithRetry(1, 0, 0),
+		clientConfig.WithCircuitBreaker("test-breaker", 2, cooldown),
+	)
+	ctx := withToken(context.Background(), "customer-token")
+	state := func() clientConfig.BreakerState {
+		return clientConfig.BreakerStates()["test-breaker"]
+	}
+
+	// Two failed calls reach the threshold and open the breaker
+	for range 2 {
+		_, _ = client.GetUserByToken(ctx)
+	}
+	if state() != clientConfig.BreakerOpen {
+		t.Fatalf("expected the breaker to be open, got %s", state())
+	}
+
+	// While open, calls fail fast without reaching the service
+	_, err := client.GetUserByToken(ctx)
+	if !errors.Is(err, clientConfig.ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if calls := service.calls.Load(); calls != 2 {
+		t.Errorf("expected the open breaker to skip the service, got %d calls", calls)
+	}
+
+	// After the cooldown a failed probe opens it again
+	time.Sleep(cooldown)
+	if state() != clientConfig.BreakerHalfOpen {
+		t.Fatalf("expected the breaker to be half-open, got %s", state())
+	}
+	_, _ = client.GetUserByToken(ctx)
+	if state() != clientConfig.BreakerOpen {
+		t.Fatalf("expected a failed probe to reopen the breaker, got %s", state())
+	}
+
+	// A successful probe closes it
+	time.Sleep(cooldown)
+	healthy.Store(true)
+	_, err = client.GetUserByToken(ctx)
+	if err != nil {
+		t.Fatalf("expected the probe to succeed, got %v", err)
+	}
+	if state() != clientConfig.BreakerClosed {
+		t.Errorf("expected a successful probe to close the breaker, got %s", state())
+	}
+}
@@ -8,12 +8,17 @@ import (
 	clientsConfig "field-service/clients/config"
 	clientUser "field-service/clients/user"
 	"field-service/config"
+	"time"
 )
 
 // ClientRegistry adalah struktur yang bertindak sebagai factory untuk semua HTTP client.
 // Registry ini menyediakan akses terpusat ke berbagai client yang dikonfigurasi
 // dengan pengaturan yang sesuai dari konfigurasi aplikasi.
-type ClientRegistry struct{}
+type ClientRegistry struct {
+	// user adalah UserClient yang dibungkus cache, dibuat sekali agar cache
+	// dipakai bersama oleh semua request
+	user *clientUser.CachedUserClient
+}
 
 // IClientRegistry adalah interface yang mendefinisikan kontrak untuk registry client.
 // Interface ini memungkinkan dependency injection dan memudahkan testing dengan mock.
@@ -21,6 +26,8 @@ type ClientRegistry struct{}
 type IClientRegistry interface {
 	// UserSvc mengembalikan client untuk berkomunikasi dengan User Service
 	UserSvc() clientUser.IUserClient
+	// UserCache mengembalikan cache lookup user untuk menghapus token yang sudah logout
+	UserCache() clientUser.IUserCache
 }
 
 // NewClientRegistry membuat instance baru dari ClientRegistry.
@@ -30,12 +37,42 @@ type IClientRegistry interface {
 // Returns:
 //   - IClientRegistry: interface yang menyediakan akses ke semua client
 func NewClientRegistry() IClientRegistry {
-	return &ClientRegistry{}
+	userCache := config.Config.UserCache
+	userConfig := config.Config.InternalService.User
+	userClientConfig := clientsConfig.NewClientConfig(
+		clientsConfig.WithBaseURL(config.Config.InternalService.User.Host),
+		clientsConfig.WithSignatureKey(config.Config.InternalService.User.SignatureKey),
+		clientsConfig.WithTimeout(time.Duration(userConfig.TimeoutSecond)*time.Second),
+		clientsConfig.WithRetry(
+			userConfig.RetryMaxAttempts,
+			time.Duration(userConfig.RetryBaseDelayMillisecond)*time.Millisecond,
+			time.Duration(userConfig.RetryMaxDelayMillisecond)*time.Millisecond,
+		),
+		clientsConfig.WithCircuitBreaker(
+			"user-service",
+			userConfig.BreakerThreshold,
+			time.Duration(userConfig.BreakerCooldownSecond)*time.Second,
+		),
+	)
+
+	// Ikuti perubahan host dan signature key User Service saat config di-reload
+	config.Subscribe(func(_, next *config.AppConfig) {
+		userClientConfig.SetEndpoint(next.InternalService.User.Host, next.InternalService.User.SignatureKey)
+	})
+
+	return &ClientRegistry{
+		user: clientUser.NewCachedUserClient(
+			clientUser.NewUserClient(userClientConfig),
+			clientUser.WithCacheTTL(time.Duration(userCache.TTLSecond)*time.Second),
+			clientUser.WithCacheNegativeTTL(time.Duration(userCache.NegativeTTLSecond)*time.Second),
+			clientUser.WithCacheMaxSize(userCache.MaxSize),
+		),
+	}
 }
 
 // UserSvc mengembalikan client yang dikonfigurasi untuk berkomunikasi dengan User Service.
-// Method ini membuat instance baru dari UserClient dengan konfigurasi yang diambil
-// dari konfigurasi aplikasi (config.Config).
+// Client ini dibungkus cache sehingga lookup token yang sama tidak selalu
+// memanggil User Service.
 //
 // Returns:
 //   - clientUser.IUserClient: client yang siap digunakan untuk User Service
@@ -43,11 +80,15 @@ func NewClientRegistry() IClientRegistry {
 // Configuration:
 //   - BaseURL: diambil dari config.Config.InternalService.User.Host
 //   - SignatureKey: diambil dari config.Config.InternalService.User.SignatureKey
+//   - Cache: diambil dari config.Config.UserCache
 func (c *ClientRegistry) UserSvc() clientUser.IUserClient {
-	return clientUser.NewUserClient(
-		clientsConfig.NewClientConfig(
-			clientsConfig.WithBaseURL(config.Config.InternalService.User.Host),
-			clientsConfig.WithSignatureKey(config.Config.InternalService.User.SignatureKey),
-		),
-	)
+	return c.user
+}
+
+// UserCache mengembalikan cache lookup user yang dipakai oleh UserSvc.
+//
+// Returns:
+//   - clientUser.IUserCache: cache yang dapat menghapus token tertentu
+func (c *ClientRegistry) UserCache() clientUser.IUserCache {
+	return c.user
 }
@@ -0,0 +1,227 @@
+package clients
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"field-service/common/utils"
+	"field-service/constants"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+)
+
+/*
+ * TUJUAN FILE INI:
+ * File ini berisi decorator cache untuk IUserClient. Hasil GetUserByToken
+ * disimpan di memory dengan key berupa hash SHA256 dari bearer token, sehingga
+ * request berikutnya dengan token yang sama tidak perlu memanggil User Service.
+ *
+ * ATURAN CACHE:
+ * 1. Lookup yang berhasil disimpan selama ttl
+ * 2. Token yang ditolak User Service dengan status 4xx disimpan selama
+ *    negativeTTL (lebih singkat) agar token yang tidak valid tidak membanjiri
+ *    User Service. Timeout, pembatalan, circuit open dan error 5xx tidak
+ *    disimpan karena tidak menyatakan apa pun tentang token
+ * 3. Jumlah entry dibatasi maxSize, entry yang paling cepat kedaluwarsa
+ *    dibuang lebih dulu ketika cache penuh. Urutan kedaluwarsa disimpan di
+ *    min-heap sehingga eviction tidak perlu menyalin seluruh isi cache
+ * 4. Token dapat dihapus dari cache lewat Evict, misalnya ketika user logout
+ */
+
+const (
+	defaultCacheTTL         = 5 * time.Minute
+	defaultCacheNegativeTTL = 10 * time.Second
+	defaultCacheMaxSize     = 10000
+)
+
+// cacheEntry menyimpan hasil lookup, baik data user maupun error
+type cacheEntry struct {
+	user      *UserData
+	err       error
+	expiresAt int64 // Waktu kedaluwarsa dalam UnixNano, sama dengan posisinya di expiryQueue
+}
+
+// expiry adalah posisi sebuah key di urutan kedaluwarsa
+type expiry struct {
+	key       string
+	expiresAt int64
+}
+
+// expiryQueue adalah min-heap expiry, entry yang paling cepat kedaluwarsa di depan
+type expiryQueue []expiry
+
+func (q expiryQueue) Len() int           { return len(q) }
+func (q expiryQueue) Less(i, j int) bool { return q[i].expiresAt < q[j].expiresAt }
+func (q expiryQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *expiryQueue) Push(value any) {
+	*q = append(*q, value.(expiry))
+}
+
+func (q *expiryQueue) Pop() any {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// CachedUserClient struct decorator yang membungkus IUserClient dengan cache
+type CachedUserClient struct {
+	next        IUserClient  // Client asli yang dipanggil ketika cache miss
+	cache       *cache.Cache // Penyimpanan cache in-memory
+	ttl         time.Duration
+	negativeTTL time.Duration
+	maxSize     int
+	expiries    expiryQueue // Urutan kedaluwarsa entry untuk eviction
+	mutex       sync.Mutex  // Menjaga eviction dan insert agar tidak melebihi maxSize
+}
+
+// IUserCache interface untuk menghapus token dari cache
+type IUserCache interface {
+	Evict(token string) // Method untuk menghapus cache milik sebuah token
+}
+
+// CacheOption function type untuk mengkonfigurasi CachedUserClient
+type CacheOption func(*CachedUserClient)
+
+// WithCacheTTL option untuk mengatur lama penyimpanan lookup yang berhasil
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(c *CachedUserClient) {
+		if ttl > 0 {
+			c.ttl = ttl
+		}
+	}
+}
+
+// WithCacheNegativeTTL option untuk mengatur lama penyimpanan lookup yang gagal
+func WithCacheNegativeTTL(ttl time.Duration) CacheOption {
+	return func(c *CachedUserClient) {
+		if ttl > 0 {
+			c.negativeTTL = ttl
+		}
+	}
+}
+
+// WithCacheMaxSize option untuk mengatur jumlah maksimal entry di cache
+func WithCacheMaxSize(maxSize int) CacheOption {
+	return func(c *CachedUserClient) {
+		if maxSize > 0 {
+			c.maxSize = maxSize
+		}
+	}
+}
+
+// NewCachedUserClient factory function untuk membuat decorator cache baru
+// Parameter: next - client asli, options - konfigurasi cache
+// Return: *CachedUserClient yang mengimplementasikan IUserClient dan IUserCache
+func NewCachedUserClient(next IUserClient, options ...CacheOption) *CachedUserClient {
+	client := &CachedUserClient{
+		next:        next,
+		ttl:         defaultCacheTTL,
+		negativeTTL: defaultCacheNegativeTTL,
+		maxSize:     defaultCacheMaxSize,
+	}
+
+	for _, option := range options {
+		option(client)
+	}
+
+	// Cleanup interval mengikuti ttl agar entry kedaluwarsa rutin dibersihkan
+	client.cache = cache.New(client.ttl, client.ttl)
+	return client
+}
+
+// cacheKey membuat key cache dari hash token agar token asli tidak disimpan
+func cacheKey(token string) string {
+	return utils.GenerateSHA256(token)
+}
+
+// GetUserByToken mengambil data user dari cache, atau dari User Service jika cache miss
+// Parameter: ctx - context yang berisi token user
+// Return: *UserData berisi informasi user, atau error jika gagal
+func (c *CachedUserClient) GetUserByToken(ctx context.Context) (*UserData, error) {
+	// Step 1: Ambil token dari context, tanpa token tidak ada yang bisa di-cache
+	token, _ := ctx.Value(constants.Token).(string)
+	if token == "" {
+		return c.next.GetUserByToken(ctx)
+	}
+
+	// Step 2: Cek cache, return hasil sebelumnya jika ada
+	key := cacheKey(token)
+	if value, found := c.cache.Get(key); found {
+		entry := value.(cacheEntry)
+		return entry.user, entry.err
+	}
+
+	// Step 3: Cache miss, panggil User Service
+	user, err := c.next.GetUserByToken(ctx)
+
+	// Step 4: Simpan hasil, penolakan token memakai negativeTTL dan error
+	// lain tidak disimpan agar request berikutnya mencoba lagi
+	switch {
+	case err == nil:
+		c.set(key, cacheEntry{user: user}, c.ttl)
+	case isRejection(err):
+		c.set(key, cacheEntry{err: err}, c.negativeTTL)
+	}
+
+	return user, err
+}
+
+// isRejection mengecek apakah User Service menolak token dengan status 4xx.
+// 408 dan 429 tidak termasuk karena hanya berarti User Service sedang sibuk.
+func isRejection(err error) bool {
+	var responseError *ResponseError
+	if !errors.As(err, &responseError) {
+		return false
+	}
+
+	switch responseError.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return responseError.StatusCode >= http.StatusBadRequest && responseError.StatusCode < http.StatusInternalServerError
+}
+
+// Evict menghapus cache milik token, dipanggil ketika user logout
+func (c *CachedUserClient) Evict(token string) {
+	c.cache.Delete(cacheKey(token))
+}
+
+// set menyimpan entry ke cache dengan memastikan jumlah entry tidak melebihi maxSize
+func (c *CachedUserClient) set(key string, entry cacheEntry, ttl time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+
+	// Step 1: Buang entry yang sudah kedaluwarsa dari depan antrian
+	for c.expiries.Len() > 0 && c.expiries[0].expiresAt <= now.UnixNano() {
+		c.evict(heap.Pop(&c.expiries).(expiry))
+	}
+
+	// Step 2: Cache masih penuh, buang entry yang paling cepat kedaluwarsa.
+	// Key yang sudah ada tidak menambah jumlah entry sehingga tidak perlu tempat
+	_, exists := c.cache.Get(key)
+	for !exists && c.cache.ItemCount() >= c.maxSize && c.expiries.Len() > 0 {
+		c.evict(heap.Pop(&c.expiries).(expiry))
+	}
+
+	// Step 3: Simpan entry dan catat posisinya di urutan kedaluwarsa
+	entry.expiresAt = now.Add(ttl).UnixNano()
+	c.cache.Set(key, entry, ttl)
+	heap.Push(&c.expiries, expiry{key: key, expiresAt: entry.expiresAt})
+}
+
+// evict menghapus entry milik item antrian. Key yang sudah disimpan ulang
+// dibiarkan karena posisinya yang baru ada di item antrian lain.
+// Harus dipanggil saat mutex sudah di-lock.
+func (c *CachedUserClient) evict(item expiry) {
+	if value, found := c.cache.Get(item.key); found && value.(cacheEntry).expiresAt != item.expiresAt {
+		return
+	}
+	c.cache.Delete(item.key)
+}
@@ -0,0 +1,144 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	clientConfig "field-service/clients/config"
+	"field-service/constants"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// stubUserClient answers every lookup with err and counts the calls.
+type stubUserClient struct {
+	err   error
+	calls int
+}
+
+func (s *stubUserClient) GetUserByToken(context.Context) (*UserData, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &UserData{Username: "customer"}, nil
+}
+
+func withToken(token string) context.Context {
+	return context.WithValue(context.Background(), constants.Token, token)
+}
+
+func cached(c *CachedUserClient, key string) bool {
+	_, found := c.cache.Get(key)
+	return found
+}
+
+func TestCacheEvictsSoonestExpiryAtMaxSize(t *testing.T) {
+	c := NewCachedUserClient(&stubUserClient{}, WithCacheMaxSize(2))
+
+	c.set("a", cacheEntry{}, 3*time.Minute)
+	c.set("b", cacheEntry{}, time.Minute)
+	c.set("c", cacheEntry{}, 2*time.Minute)
+
+	if count := c.cache.ItemCount(); count != 2 {
+		t.Fatalf("expected maxSize entries, got %d", count)
+	}
+	if cached(c, "b") {
+		t.Error("expected the soonest expiring entry to be evicted")
+	}
+	if !cached(c, "a") || !cached(c, "c") {
+		t.Error("expected the later expiring entries to stay")
+	}
+}
+
+func TestCacheKeepsResetKeyOverItsStaleExpiry(t *testing.T) {
+	c := NewCachedUserClient(&stubUserClient{}, WithCacheMaxSize(2))
+
+	c.set("a", cacheEntry{}, time.Minute)
+	c.set("b", cacheEntry{}, 2*time.Minute)
+	// Setting a again while full replaces it without evicting b, and leaves
+	// its old expiry at the head of the queue
+	c.set("a", cacheEntry{}, 3*time.Minute)
+	if !cached(c, "b") {
+		t.Fatal("expected re-setting a key not to evict another entry")
+	}
+
+	c.set("c", cacheEntry{}, 30*time.Second)
+	if !cached(c, "a") {
+		t.Error("expected the stale expiry of a not to evict it")
+	}
+	if cached(c, "b") {
+		t.Error("expected b to be evicted as the soonest live expiry")
+	}
+	if count := c.cache.ItemCount(); count != 2 {
+		t.Errorf("expected maxSize entries, got %d", count)
+	}
+}
+
+func TestCacheNegativeCachesRejections(t *testing.T) {
+	negativeTTL := 50 * time.Millisecond
+	stub := &stubUserClient{err: &ResponseError{StatusCode: http.StatusUnauthorized, Message: "unauthorized"}}
+	c := NewCachedUserClient(stub, WithCacheNegativeTTL(negativeTTL))
+	ctx := withToken("rejected-token")
+
+	for range 2 {
+		_, err := c.GetUserByToken(ctx)
+		if err == nil {
+			t.Fatal("expected the rejection to be returned")
+		}
+	}
+	if stub.calls != 1 {
+		t.Fatalf("expected the rejection to be cached, got %d calls", stub.calls)
+	}
+
+	time.Sleep(negativeTTL)
+	_, _ = c.GetUserByToken(ctx)
+	if stub.calls != 2 {
+		t.Errorf("expected a fresh lookup after negativeTTL, got %d calls", stub.calls)
+	}
+}
+
+func TestCacheDoesNotCacheTransientErrors(t *testing.T) {
+	for name, err := range map[string]error{
+		"server error":      &clientConfig.ServerError{StatusCode: http.StatusServiceUnavailable},
+		"timeout":           context.DeadlineExceeded,
+		"cancelled":         context.Canceled,
+		"circuit open":      clientConfig.ErrCircuitOpen,
+		"request timeout":   &ResponseError{StatusCode: http.StatusRequestTimeout},
+		"too many requests": &ResponseError{StatusCode: http.StatusTooManyRequests},
+	} {
+		t.Run(name, func(t *testing.T) {
+			stub := &stubUserClient{err: err}
+			c := NewCachedUserClient(stub)
+			ctx := withToken("token")
+
+			for range 2 {
+				_, lookupErr := c.GetUserByToken(ctx)
+				if !errors.Is(lookupErr, err) {
+					t.Fatalf("expected %v, got %v", err, lookupErr)
+				}
+			}
+			if stub.calls != 2 {
+				t.Errorf("expected every lookup to reach user-service, got %d calls", stub.calls)
+			}
+		})
+	}
+}
+
+func TestCacheEvictForcesFreshLookup(t *testing.T) {
+	stub := &stubUserClient{}
+	c := NewCachedUserClient(stub)
+	ctx := withToken("token")
+
+	_, _ = c.GetUserByToken(ctx)
+	_, _ = c.GetUserByToken(ctx)
+	if stub.calls != 1 {
+		t.Fatalf("expected the user to be cached, got %d calls", stub.calls)
+	}
+
+	c.Evict("token")
+	_, _ = c.GetUserByToken(ctx)
+	if stub.calls != 2 {
+		t.Errorf("expected a fresh lookup after Evict, got %d calls", stub.calls)
+	}
+}
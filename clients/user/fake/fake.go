@@ -0,0 +1,150 @@
+// Package fake menyediakan stub User Service untuk development lokal dan test.
+// Stub ini melayani endpoint /api/v1/auth/user dengan envelope yang sama
+// seperti clients.UserResponse, dengan data user dan token dari file fixture.
+package fake
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	clients "field-service/clients/user"
+	"field-service/common/utils"
+	"field-service/constants"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+)
+
+// UserPath adalah endpoint User Service yang dipanggil oleh UserClient
+const UserPath = "/api/v1/auth/user"
+
+// FixtureUser adalah satu user di file fixture beserta token yang dimilikinya
+type FixtureUser struct {
+	Token string `json:"token"`
+	clients.UserData
+}
+
+// Fixture adalah isi file fixture
+type Fixture struct {
+	Users []FixtureUser `json:"users"`
+}
+
+// LoadFixture membaca file fixture JSON
+//
+// Parameters:
+//   - path: lokasi file fixture
+//
+// Returns:
+//   - *Fixture: data user dari file fixture
+//   - error: error jika file tidak bisa dibaca atau bukan JSON yang valid
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixture Fixture
+	err = json.Unmarshal(data, &fixture)
+	if err != nil {
+		return nil, fmt.Errorf("parse fixture %s: %w", path, err)
+	}
+
+	return &fixture, nil
+}
+
+// Handler adalah http.Handler yang meniru User Service
+type Handler struct {
+	users        map[string]clients.UserData // User berdasarkan token
+	signatureKey string                      // Signature key untuk validasi x-api-key
+}
+
+// NewHandler membuat handler stub User Service
+//
+// Parameters:
+//   - fixture: data user dan token
+//   - signatureKey: key yang sama dengan internalService.user.signatureKey milik caller
+//
+// Returns:
+//   - *Handler: handler yang siap dipasang ke http.Server atau httptest.Server
+func NewHandler(fixture *Fixture, signatureKey string) *Handler {
+	users := make(map[string]clients.UserData, len(fixture.Users))
+	for _, user := range fixture.Users {
+		users[user.Token] = user.UserData
+	}
+
+	return &Handler{
+		users:        users,
+		signatureKey: signatureKey,
+	}
+}
+
+// NewServer menjalankan stub User Service di httptest.Server untuk test.
+// Pemanggil wajib memanggil Close setelah selesai.
+//
+// Example:
+//
+//	server := fake.NewServer(fixture, "secret")
+//	defer server.Close()
+//	client := clientConfig.NewClientConfig(clientConfig.WithBaseURL(server.URL), ...)
+func NewServer(fixture *Fixture, signatureKey string) *httptest.Server {
+	return httptest.NewServer(NewHandler(fixture, signatureKey))
+}
+
+// ServeHTTP melayani GET /api/v1/auth/user
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Step 1: Hanya endpoint user yang dilayani
+	if r.URL.Path != UserPath {
+		writeResponse(w, http.StatusNotFound, "not found", nil)
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeResponse(w, http.StatusMethodNotAllowed, "method not allowed", nil)
+		return
+	}
+
+	// Step 2: Validasi x-api-key dengan algoritma yang sama seperti UserClient
+	if !h.validSignature(r) {
+		writeResponse(w, http.StatusUnauthorized, "unauthorized", nil)
+		return
+	}
+
+	// Step 3: Cari user berdasarkan bearer token
+	token, ok := strings.CutPrefix(r.Header.Get(constants.Authorization), "Bearer ")
+	user, found := h.users[token]
+	if !ok || !found {
+		writeResponse(w, http.StatusUnauthorized, "invalid token", nil)
+		return
+	}
+
+	writeResponse(w, http.StatusOK, "success", &user)
+}
+
+// validSignature mengecek x-api-key = sha256(serviceName:signatureKey:requestAt)
+func (h *Handler) validSignature(r *http.Request) bool {
+	serviceName := r.Header.Get(constants.XServiceName)
+	requestAt := r.Header.Get(constants.XRequestAt)
+	apiKey := r.Header.Get(constants.XApiKey)
+
+	expected := utils.GenerateSHA256(fmt.Sprintf("%s:%s:%s", serviceName, h.signatureKey, requestAt))
+	return subtle.ConstantTimeCompare([]byte(apiKey), []byte(expected)) == 1
+}
+
+// writeResponse menulis envelope yang sama dengan clients.UserResponse
+func writeResponse(w http.ResponseWriter, code int, message string, user *clients.UserData) {
+	response := clients.UserResponse{
+		Code:    code,
+		Status:  constants.Success,
+		Message: message,
+	}
+	if code != http.StatusOK {
+		response.Status = constants.Error
+	}
+	if user != nil {
+		response.Data = *user
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -2,13 +2,23 @@ package clients
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	clientConfig "field-service/clients/config"
+	"field-service/common/logger"
+	"field-service/common/metrics"
+	"field-service/common/tracing"
 	"field-service/common/utils"
 	"field-service/config"
 	"field-service/constants"
 	"fmt"
 	"net/http"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 /*
@@ -38,6 +48,17 @@ type IUserClient interface {
 	GetUserByToken(context.Context) (*UserData, error) // Method untuk mendapatkan data user dari token
 }
 
+// ResponseError dikembalikan ketika User Service membalas dengan status selain
+// 200 dan selain 5xx, misalnya 401 untuk token yang tidak valid
+type ResponseError struct {
+	StatusCode int    // Status code dari User Service
+	Message    string // Pesan error dari envelope response
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("user response: %s", e.Message)
+}
+
 // NewUserClient factory function untuk membuat instance UserClient baru
 // Parameter: client - konfigurasi HTTP client yang akan digunakan
 // Return: instance IUserClient yang siap digunakan
@@ -73,24 +94,93 @@ func (u *UserClient) GetUserByToken(ctx context.Context) (*UserData, error) {
 	var response UserResponse
 
 	// Step 6: Buat HTTP request dengan headers yang diperlukan untuk autentikasi antar service
+	url := fmt.Sprintf("%s/api/v1/auth/user", u.client.BaseURL())
 	request := u.client.Client().Clone().
-		Set(constants.Authorization, bearerToken).                  // Bearer token user
-		Set(constants.XServiceName, config.Config.AppName).         // Nama service yang melakukan request
-		Set(constants.XApiKey, apiKey).                             // API key untuk autentikasi antar service
-		Set(constants.XRequestAt, fmt.Sprintf("%d", unixTime)).     // Timestamp request
-		Get(fmt.Sprintf("%s/api/v1/auth/user", u.client.BaseURL())) // Endpoint User Service
-
-	// Step 7: Eksekusi request dan parse response ke struct UserResponse
-	resp, _, errs := request.EndStruct(&response)
-	if len(errs) > 0 {
-		return nil, errs[0] // Return error jika ada masalah dalam request
+		Set(constants.Authorization, bearerToken).              // Bearer token user
+		Set(constants.XServiceName, config.Config.AppName).     // Nama service yang melakukan request
+		Set(constants.XApiKey, apiKey).                         // API key untuk autentikasi antar service
+		Set(constants.XRequestAt, fmt.Sprintf("%d", unixTime)). // Timestamp request
+		Get(url)                                                // Endpoint User Service
+
+	// Teruskan request id agar log di User Service bisa dikorelasikan
+	if requestID := logger.RequestID(ctx); requestID != "" {
+		request.Set(constants.XRequestID, requestID)
+	}
+
+	// Buat span client dan kirim header traceparent agar trace berlanjut
+	// di User Service. Satu span mencakup semua percobaan retry
+	ctx, span := tracing.Tracer().Start(ctx, "GET /api/v1/auth/user",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(http.MethodGet),
+			semconv.URLFull(url),
+			attribute.String("peer.service", "user-service"),
+		),
+	)
+	defer span.End()
+	tracing.Inject(ctx, func(key, value string) {
+		request.Set(key, value)
+	})
+
+	// Step 7: Eksekusi request dengan timeout, retry dan circuit breaker,
+	// pembatalan ctx ikut membatalkan request
+	start := time.Now()
+	resp, body, err := u.client.Do(ctx, request)
+	observeCall(start, resp, err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err // Return error jika ada masalah dalam request
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+
+	// Parse response ke struct UserResponse, body non-JSON pada status error
+	// tetap dilaporkan lewat validasi status code di bawah
+	err = json.Unmarshal(body, &response)
+	if err != nil && resp.StatusCode == http.StatusOK {
+		return nil, err
 	}
 
 	// Step 8: Validasi status code response
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("user response: %s", response.Message)
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		return nil, &ResponseError{StatusCode: resp.StatusCode, Message: response.Message}
 	}
 
 	// Step 9: Return data user jika berhasil
 	return &response.Data, nil
 }
+
+// observeCall mencatat latency dan alasan kegagalan panggilan ke User Service
+// ke metrics Prometheus.
+//
+// Alasan kegagalan:
+//   - circuit_open: request ditolak circuit breaker
+//   - timeout: request melewati batas waktu atau dibatalkan
+//   - server_error: User Service membalas 5xx pada percobaan terakhir
+//   - network: koneksi gagal
+//   - rejected: User Service membalas selain 200, misalnya token tidak valid
+func observeCall(start time.Time, resp *http.Response, err error) {
+	outcome := "success"
+	reason := ""
+
+	var serverError *clientConfig.ServerError
+	switch {
+	case errors.Is(err, clientConfig.ErrCircuitOpen):
+		reason = "circuit_open"
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		reason = "timeout"
+	case errors.As(err, &serverError):
+		reason = "server_error"
+	case err != nil:
+		reason = "network"
+	case resp.StatusCode != http.StatusOK:
+		reason = "rejected"
+	}
+
+	if reason != "" {
+		outcome = "error"
+		metrics.UserServiceErrorsTotal.WithLabelValues(reason).Inc()
+	}
+	metrics.UserServiceRequestDuration.WithLabelValues(outcome).Observe(time.Since(start).Seconds())
+}
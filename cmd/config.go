@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"field-service/config"
+	"fmt"
+	"os"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+)
+
+var configCommand = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the service configuration",
+}
+
+var configPrintCommand = &cobra.Command{
+	Use:   "print",
+	Short: "Print the effective configuration and any validation problems",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		redact, _ := cmd.Flags().GetBool("redact")
+		_ = godotenv.Load()
+
+		cfg, _, err := config.Load(context.Background())
+		if cfg != nil {
+			effective := *cfg
+			if redact {
+				effective = effective.Redacted()
+			}
+
+			output, marshalErr := json.MarshalIndent(effective, "", "  ")
+			if marshalErr != nil {
+				return marshalErr
+			}
+			fmt.Println(string(output))
+		}
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
+func init() {
+	configPrintCommand.Flags().Bool("redact", false, "mask secrets such as signature keys and passwords")
+	configCommand.AddCommand(configPrintCommand)
+	rootCommand.AddCommand(configCommand)
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"field-service/clients/user/fake"
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var fakeUserServiceCommand = &cobra.Command{
+	Use:   "fake-user-service",
+	Short: "Start a stub user service backed by a fixture file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fixturePath, _ := cmd.Flags().GetString("fixture")
+		port, _ := cmd.Flags().GetInt("port")
+		signatureKey, _ := cmd.Flags().GetString("signature-key")
+
+		fixture, err := fake.LoadFixture(fixturePath)
+		if err != nil {
+			return err
+		}
+
+		logrus.Infof("fake user service listening on :%d with %d users", port, len(fixture.Users))
+		return http.ListenAndServe(fmt.Sprintf(":%d", port), fake.NewHandler(fixture, signatureKey))
+	},
+}
+
+func init() {
+	fakeUserServiceCommand.Flags().String("fixture", "fixtures/users.json", "path to the users fixture file")
+	fakeUserServiceCommand.Flags().Int("port", 8001, "port to listen on")
+	fakeUserServiceCommand.Flags().String("signature-key", "", "signature key callers use for x-api-key")
+	rootCommand.AddCommand(fakeUserServiceCommand)
+}
@@ -1,100 +1,46 @@
 package cmd
 
 import (
-	"field-service/common/response"
 	"field-service/config"
-	"field-service/constants"
-	"field-service/controllers"
-	"field-service/domain/models"
-	"field-service/middlewares"
-	"field-service/repositories"
-	"field-service/routes"
-	"field-service/services"
-	"fmt"
-	"net/http"
 	"time"
 
-	"github.com/didip/tollbooth"
-	"github.com/didip/tollbooth/limiter"
-	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
+	"gorm.io/gorm"
 )
 
-var command = &cobra.Command{
-	Use:   "serve",
-	Short: "Start the server",
-	Run: func(cmd *cobra.Command, args []string) {
-		_ = godotenv.Load()
-		config.Init()
-		db, err := config.InitDatabase()
-		if err != nil {
-			panic(err)
-		}
-
-		loc, err := time.LoadLocation("Asia/Jakarta")
-		if err != nil {
-			panic(err)
-		}
-		time.Local = loc
-
-		// Migration & Seeding
-		err = db.AutoMigrate(&models.Role{}, &models.User{})
-		if err != nil {
-			panic(err)
-		}
-
-		// Build Dependencies
-		repository := repositories.NewRepositoryRegistry(db)
-		service := services.NewServiceRegistry(repository)
-		controller := controllers.NewControllerRegistry(service)
-
-		// Set http Router
-		router := gin.Default()
-		router.Use(middlewares.HandlePanic())
-		router.NoRoute(func(ctx *gin.Context) {
-			ctx.JSON(http.StatusNotFound, response.Response{
-				Status:  constants.Error,
-				Message: fmt.Sprintf("Path %s", http.StatusText(http.StatusNotFound)),
-			})
-		})
+// rootCommand starts the server when no subcommand is given, so existing
+// deployments that run the bare binary keep working.
+var rootCommand = &cobra.Command{
+	Use:   "field-service",
+	Short: "Field booking service",
+	Run:   serveCommand.Run,
+}
 
-		// Default Route
-		router.GET("/", func(ctx *gin.Context) {
-			ctx.JSON(http.StatusOK, response.Response{
-				Status:  constants.Success,
-				Message: "Welcome to User Service",
-			})
-		})
+func init() {
+	rootCommand.AddCommand(serveCommand)
+}
 
-		// CORS
-		router.Use(func(ctx *gin.Context) {
-			ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-			ctx.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE")
-			ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, x-service-name, x-api-key, x-request-at")
-			ctx.Next()
-		})
+// bootstrap loads the configuration, sets the service time zone and opens
+// the database connection shared by every subcommand.
+func bootstrap() *gorm.DB {
+	_ = godotenv.Load()
+	config.Init()
 
-		// Rate Limiter
-		limimter := tollbooth.NewLimiter(
-			float64(config.Config.RateLimiterRequest),
-			&limiter.ExpirableOptions{
-				DefaultExpirationTTL: time.Duration(config.Config.RateLimiterTimeSecond) * time.Second,
-			},
-		)
-		router.Use(middlewares.RateLimit(limimter))
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		panic(err)
+	}
+	time.Local = loc
 
-		// Setup Router
-		group := router.Group("/api/v1")
-		route := routes.NewRouteRegistry(controller, group)
-		route.Serve()
+	db, err := config.InitDatabase()
+	if err != nil {
+		panic(err)
+	}
 
-		// Start Server
-		port := fmt.Sprintf(":%d", config.Config.Port)
-		router.Run(port)
-	},
+	return db
 }
 
 func Run() {
-	command.Execute()
+	rootCommand.Execute()
 }
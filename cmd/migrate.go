@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"field-service/migrations"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var migrateCommand = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage database migrations",
+}
+
+var migrateUpCommand = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		migrator, err := migrations.NewMigrator(bootstrap())
+		if err != nil {
+			return err
+		}
+
+		applied, err := migrator.Up(context.Background())
+		for _, migration := range applied {
+			fmt.Printf("applied %06d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+		return nil
+	},
+}
+
+var migrateDownCommand = &cobra.Command{
+	Use:   "down",
+	Short: "Roll back the latest migrations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		steps, _ := cmd.Flags().GetInt("steps")
+		migrator, err := migrations.NewMigrator(bootstrap())
+		if err != nil {
+			return err
+		}
+
+		reverted, err := migrator.Down(context.Background(), steps)
+		for _, migration := range reverted {
+			fmt.Printf("reverted %06d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(reverted) == 0 {
+			fmt.Println("no applied migrations")
+		}
+		return nil
+	},
+}
+
+var migrateStatusCommand = &cobra.Command{
+	Use:   "status",
+	Short: "Show which migrations have been applied",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		migrator, err := migrations.NewMigrator(bootstrap())
+		if err != nil {
+			return err
+		}
+
+		statuses, err := migrator.Status(context.Background())
+		if err != nil {
+			return err
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(writer, "%06d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return writer.Flush()
+	},
+}
+
+func init() {
+	migrateDownCommand.Flags().Int("steps", 1, "number of migrations to roll back")
+	migrateCommand.AddCommand(migrateUpCommand, migrateDownCommand, migrateStatusCommand)
+	rootCommand.AddCommand(migrateCommand)
+}
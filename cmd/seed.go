@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+	"field-service/seeders"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var seedCommand = &cobra.Command{
+	Use:   "seed",
+	Short: "Insert default time slots and demo fields",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := seeders.NewSeeder(bootstrap()).Run(context.Background())
+		if err != nil {
+			return err
+		}
+
+		logrus.Info("seeding completed")
+		return nil
+	},
+}
+
+func init() {
+	rootCommand.AddCommand(seedCommand)
+}
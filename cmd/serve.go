@@ -0,0 +1,453 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"field-service/clients"
+	"field-service/common/auth"
+	"field-service/common/gcs"
+	"field-service/common/metrics"
+	"field-service/common/response"
+	"field-service/common/storage"
+	"field-service/common/tracing"
+	"field-service/config"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+	"field-service/migrations"
+	"field-service/repositories"
+	"field-service/routes"
+	"field-service/services"
+	"field-service/subscribers"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/didip/tollbooth"
+	"github.com/didip/tollbooth/limiter"
+	"github.com/gin-gonic/gin"
+	"github.com/nats-io/nats.go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+// shutdownTimeout bounds how long in-flight requests, background workers
+// and the NATS drain get to finish once SIGINT or SIGTERM arrives.
+const shutdownTimeout = 20 * time.Second
+
+var serveCommand = &cobra.Command{
+	Use:   "serve",
+	Short: "Start the server",
+	Run: func(cmd *cobra.Command, args []string) {
+		// Cancelled on SIGINT or SIGTERM, which stops the workers and the server
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		db := bootstrap()
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+
+		// Tracing
+		shutdownTracing := initTracing()
+		if shutdownTracing != nil {
+			defer shutdownTracing(context.Background())
+		}
+
+		err := db.Use(metrics.NewGormPlugin())
+		if err != nil {
+			panic(err)
+		}
+
+		err = db.Use(tracing.NewGormPlugin())
+		if err != nil {
+			panic(err)
+		}
+
+		// Migration
+		err = migrate(db)
+		if err != nil {
+			panic(err)
+		}
+
+		// Build Dependencies
+		client := clients.NewClientRegistry()
+		middlewares.SetTokenVerifier(initTokenVerifier())
+		repository := repositories.NewRepositoryRegistry(db)
+		service := services.NewServiceRegistry(repository, initStorage())
+		controller := controllers.NewControllerRegistry(service, client)
+
+		// Background Workers
+		var workers sync.WaitGroup
+		runWorker(&workers, func() { runHoldSweeper(ctx, service) })
+
+		// Event Subscribers
+		natsConn := initNATS()
+		if natsConn != nil {
+			defer drainNATS(natsConn)
+
+			orderSubscriber := subscribers.NewOrderSubscriber(
+				natsConn,
+				service.GetFieldSchedule(),
+				subscribers.OrderSubjects{
+					Paid:      config.Config.NATS.OrderPaidSubject,
+					Cancelled: config.Config.NATS.OrderCancelledSubject,
+					Expired:   config.Config.NATS.OrderExpiredSubject,
+				},
+				subscribers.OrderConsumer{
+					Stream:     config.Config.NATS.Stream,
+					QueueGroup: config.Config.NATS.QueueGroup,
+					MaxDeliver: config.Config.NATS.MaxDeliver,
+					RetryDelay: time.Duration(config.Config.NATS.RetryDelaySecond) * time.Second,
+				},
+			)
+			err = orderSubscriber.Start()
+			if err != nil {
+				panic(err)
+			}
+
+			runWorker(&workers, func() { runOutboxRelay(ctx, service, natsConn) })
+		}
+
+		// Set http Router
+		router := gin.New()
+		router.Use(
+			middlewares.RequestID(),
+			middlewares.Tracing(),
+			middlewares.AccessLog(),
+			middlewares.Metrics(),
+			middlewares.HandlePanic(),
+		)
+		router.NoRoute(func(ctx *gin.Context) {
+			ctx.JSON(http.StatusNotFound, response.Response{
+				Status:  constants.Error,
+				Message: fmt.Sprintf("Path %s", http.StatusText(http.StatusNotFound)),
+			})
+		})
+
+		// Default Route
+		router.GET("/", func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, response.Response{
+				Status:  constants.Success,
+				Message: "Welcome to Field Service",
+			})
+		})
+
+		// Metrics
+		if config.Config.Metrics.Enabled {
+			prometheus.MustRegister(
+				metrics.NewFieldScheduleCollector(repository.GetFieldSchedule()),
+				metrics.NewCircuitBreakerCollector(),
+			)
+			router.GET(config.Config.Metrics.Path, middlewares.MetricsAuth(), gin.WrapH(promhttp.Handler()))
+		}
+
+		// Local Storage
+		if config.Config.StorageBackend == storage.BackendLocal {
+			router.Static(config.Config.LocalStorage.RoutePath, config.Config.LocalStorage.Directory)
+		}
+
+		// CORS
+		router.Use(func(ctx *gin.Context) {
+			ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+			ctx.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE")
+			ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, x-service-name, x-api-key, x-request-at, x-request-nonce, x-request-id, traceparent, tracestate")
+			ctx.Writer.Header().Set("Access-Control-Expose-Headers", "x-request-id")
+			ctx.Next()
+		})
+
+		// Rate Limiter
+		limimter := tollbooth.NewLimiter(
+			float64(config.Config.RateLimiterRequest),
+			&limiter.ExpirableOptions{
+				DefaultExpirationTTL: time.Duration(config.Config.RateLimiterTimeSecond) * time.Second,
+			},
+		)
+		router.Use(middlewares.RateLimit(limimter))
+		config.Subscribe(func(_, next *config.AppConfig) {
+			limimter.SetMax(float64(next.RateLimiterRequest))
+		})
+
+		// Config Hot Reload
+		watcher := config.NewWatcherFromEnv()
+		if watcher != nil {
+			runWorker(&workers, func() { watcher.Run(ctx) })
+		}
+
+		// Setup Router
+		group := router.Group("/api/v1")
+		route := routes.NewRouteRegistry(controller, group, client)
+		route.Serve()
+
+		// Start Server
+		server := &http.Server{
+			Addr:    fmt.Sprintf(":%d", config.Config.Port),
+			Handler: router,
+		}
+		serverErr := make(chan error, 1)
+		go func() {
+			logrus.Infof("listening on %s", server.Addr)
+			serverErr <- server.ListenAndServe()
+		}()
+
+		select {
+		case err := <-serverErr:
+			logrus.Errorf("server stopped: %v", err)
+			stop()
+		case <-ctx.Done():
+			logrus.Info("shutting down")
+		}
+
+		// Stop accepting requests and wait for in-flight ones, then for the
+		// workers, before the deferred NATS drain and tracing flush run
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		err = server.Shutdown(shutdownCtx)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.Errorf("failed to shut down server: %v", err)
+		}
+		workers.Wait()
+	},
+}
+
+// runWorker runs worker in a goroutine tracked by workers.
+func runWorker(workers *sync.WaitGroup, worker func()) {
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		worker()
+	}()
+}
+
+// drainNATS finishes the messages being handled and flushes pending publishes
+// before closing the connection. Drain is asynchronous, so it waits for the
+// connection to close, up to shutdownTimeout.
+func drainNATS(conn *nats.Conn) {
+	err := conn.Drain()
+	if err != nil {
+		logrus.Errorf("failed to drain nats connection: %v", err)
+		conn.Close()
+		return
+	}
+
+	deadline := time.Now().Add(shutdownTimeout)
+	for !conn.IsClosed() && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// migrate applies pending migrations outside production. In production the
+// schema is only changed through `migrate up`, so pending ones are reported.
+func migrate(db *gorm.DB) error {
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	if config.Config.AppEnv == constants.Production {
+		pending, err := migrator.Pending(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if len(pending) > 0 {
+			logrus.Warnf("%d database migrations are pending, run `migrate up`", len(pending))
+		}
+		return nil
+	}
+
+	applied, err := migrator.Up(context.Background())
+	for _, migration := range applied {
+		logrus.Infof("applied migration %06d_%s", migration.Version, migration.Name)
+	}
+	return err
+}
+
+func initGCS() gcs.IGCSClient {
+	return gcs.NewGCSClient(
+		gcs.ServiceAccountKeyJSON{
+			Type:                    config.Config.GCSType,
+			ProjectID:               config.Config.GCSProjectID,
+			PrivateKeyID:            config.Config.GCSPrivateKeyID,
+			PrivateKey:              config.Config.GCSPrivateKey,
+			ClientEmail:             config.Config.GCSClientEmail,
+			ClientID:                config.Config.GCSClientID,
+			AuthURI:                 config.Config.GCSAuthURI,
+			TokenURI:                config.Config.GCSTokenURI,
+			AuthProviderX509CertURL: config.Config.GCSAuthProviderX509CertURL,
+			ClientX509CertURL:       config.Config.GCSClientX509CertURL,
+			UniverseDomain:          config.Config.GCSUniverseDomain,
+		},
+		config.Config.GCSBucketName,
+	)
+}
+
+// initTokenVerifier returns nil when no public key is configured, in which
+// case roles keep being resolved through user-service.
+func initTokenVerifier() auth.IVerifier {
+	jwtConfig := config.Config.JWT
+	if jwtConfig.PublicKey == "" && jwtConfig.PublicKeyFile == "" && jwtConfig.JWKSFile == "" {
+		logrus.Warn("jwt public key is not configured, resolving users through user service")
+		return nil
+	}
+
+	verifier, err := auth.NewVerifier(auth.Options{
+		PublicKey:     jwtConfig.PublicKey,
+		PublicKeyFile: jwtConfig.PublicKeyFile,
+		JWKSFile:      jwtConfig.JWKSFile,
+		Issuer:        jwtConfig.Issuer,
+		Audience:      jwtConfig.Audience,
+		RoleClaim:     jwtConfig.RoleClaim,
+		Leeway:        time.Duration(jwtConfig.LeewaySecond) * time.Second,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return verifier
+}
+
+func initStorage() storage.IStorageClient {
+	if config.Config.StorageBackend == storage.BackendLocal {
+		return storage.NewLocalClient(
+			config.Config.LocalStorage.Directory,
+			config.Config.LocalStorage.BaseURL,
+			config.Config.LocalStorage.RoutePath,
+		)
+	}
+
+	return initGCS()
+}
+
+// initTracing returns nil when tracing is disabled. Incoming traceparent
+// headers are still passed on to outgoing calls in that case.
+func initTracing() tracing.ShutdownFunc {
+	if !config.Config.Tracing.Enabled {
+		tracing.Propagate()
+		return nil
+	}
+
+	shutdown, err := tracing.Init(context.Background(), tracing.Options{
+		ServiceName: config.Config.AppName,
+		Environment: config.Config.AppEnv,
+		Exporter:    config.Config.Tracing.Exporter,
+		Endpoint:    config.Config.Tracing.Endpoint,
+		Insecure:    config.Config.Tracing.Insecure,
+		SampleRatio: config.Config.Tracing.SampleRatio,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	return shutdown
+}
+
+// initNATS returns nil when no NATS url is configured, in which case order
+// events are not consumed.
+func initNATS() *nats.Conn {
+	if config.Config.NATS.URL == "" {
+		logrus.Warn("nats url is not configured, order events are not consumed")
+		return nil
+	}
+
+	conn, err := nats.Connect(
+		config.Config.NATS.URL,
+		nats.Name(config.Config.AppName),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	return conn
+}
+
+// runHoldSweeper periodically returns field schedules whose hold has expired
+// back to available.
+func runHoldSweeper(ctx context.Context, service services.IServiceRegistry) {
+	interval := time.Duration(config.Config.HoldSweeperIntervalSecond) * time.Second
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			total, err := service.GetFieldSchedule().ReleaseExpiredHolds(ctx)
+			if err != nil {
+				logrus.Errorf("failed to release expired holds: %v", err)
+				continue
+			}
+
+			if total > 0 {
+				logrus.Infof("released %d expired field schedule holds", total)
+			}
+		}
+	}
+}
+
+// runOutboxRelay publishes pending outbox events to NATS. A full batch is
+// followed immediately by the next one so a backlog drains quickly. Once an
+// hour sent events older than the retention are deleted.
+func runOutboxRelay(ctx context.Context, service services.IServiceRegistry, publisher *nats.Conn) {
+	interval := time.Duration(config.Config.NATS.OutboxRelayIntervalSecond) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	batchSize := config.Config.NATS.OutboxBatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	retention := time.Duration(config.Config.NATS.OutboxRetentionHour) * time.Hour
+	if retention <= 0 {
+		retention = 72 * time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	cleanup := time.NewTicker(time.Hour)
+	defer cleanup.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-cleanup.C:
+			total, err := service.GetOutbox().DeleteSentBefore(ctx, time.Now().Add(-retention))
+			if err != nil {
+				logrus.Errorf("failed to delete sent outbox events: %v", err)
+				continue
+			}
+
+			if total > 0 {
+				logrus.Infof("deleted %d sent outbox events", total)
+			}
+		case <-ticker.C:
+			for {
+				total, err := service.GetOutbox().Relay(ctx, publisher, batchSize)
+				if err != nil {
+					logrus.Errorf("failed to relay outbox events: %v", err)
+					break
+				}
+
+				if total < batchSize {
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const defaultRoleClaim = "role"
+
+var (
+	ErrInvalidToken   = errors.New("invalid token")
+	ErrUnknownKey     = errors.New("unknown token signing key")
+	ErrMissingRole    = errors.New("token does not contain a role")
+	ErrNoKeyAvailable = errors.New("no public key configured")
+
+	validMethods = []string{
+		"RS256", "RS384", "RS512",
+		"PS256", "PS384", "PS512",
+		"ES256", "ES384", "ES512",
+		"EdDSA",
+	}
+)
+
+// Options configures where the verifier loads its public keys from and which
+// registered claims it enforces. At least one key source must be set.
+type Options struct {
+	PublicKey     string
+	PublicKeyFile string
+	JWKSFile      string
+	Issuer        string
+	Audience      string
+	RoleClaim     string
+	Leeway        time.Duration
+}
+
+// Claims is the user identity carried by a verified token.
+type Claims struct {
+	UUID        uuid.UUID
+	Name        string
+	Username    string
+	Email       string
+	Role        string
+	PhoneNumber string
+}
+
+type Verifier struct {
+	keys      map[string]any
+	parser    *jwt.Parser
+	roleClaim string
+}
+
+type IVerifier interface {
+	Verify(string) (*Claims, error)
+}
+
+func NewVerifier(opts Options) (IVerifier, error) {
+	keys := make(map[string]any)
+
+	publicKey := []byte(opts.PublicKey)
+	if len(publicKey) == 0 && opts.PublicKeyFile != "" {
+		var err error
+		publicKey, err = os.ReadFile(opts.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("read public key: %w", err)
+		}
+	}
+
+	if len(publicKey) > 0 {
+		key, err := parsePublicKeyPEM(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		keys[""] = key
+	}
+
+	if opts.JWKSFile != "" {
+		data, err := os.ReadFile(opts.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("read jwks: %w", err)
+		}
+
+		jwksKeys, err := parseJWKS(data)
+		if err != nil {
+			return nil, err
+		}
+		for kid, key := range jwksKeys {
+			keys[kid] = key
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrNoKeyAvailable
+	}
+
+	parserOptions := []jwt.ParserOption{
+		jwt.WithValidMethods(validMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(opts.Leeway),
+	}
+	if opts.Issuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(opts.Issuer))
+	}
+	if opts.Audience != "" {
+		parserOptions = append(parserOptions, jwt.WithAudience(opts.Audience))
+	}
+
+	roleClaim := opts.RoleClaim
+	if roleClaim == "" {
+		roleClaim = defaultRoleClaim
+	}
+
+	return &Verifier{
+		keys:      keys,
+		parser:    jwt.NewParser(parserOptions...),
+		roleClaim: roleClaim,
+	}, nil
+}
+
+func parsePublicKeyPEM(data []byte) (any, error) {
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseECPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("unsupported public key format")
+}
+
+// keyFunc picks the verification key by the token's kid header. Tokens
+// without a kid are accepted only when a single key is configured.
+func (v *Verifier) keyFunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	if key, ok := v.keys[kid]; ok {
+		return key, nil
+	}
+
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, nil
+		}
+	}
+
+	return nil, ErrUnknownKey
+}
+
+func (v *Verifier) Verify(tokenString string) (*Claims, error) {
+	mapClaims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(tokenString, mapClaims, v.keyFunc)
+	if err != nil {
+		if errors.Is(err, ErrUnknownKey) {
+			return nil, ErrUnknownKey
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	return v.toClaims(mapClaims)
+}
+
+// toClaims reads the user from a nested "user" object when the issuer wraps
+// it, otherwise from the top-level claims.
+func (v *Verifier) toClaims(mapClaims jwt.MapClaims) (*Claims, error) {
+	source := map[string]any(mapClaims)
+	for key, value := range mapClaims {
+		if strings.EqualFold(key, "user") {
+			if user, ok := value.(map[string]any); ok {
+				source = user
+			}
+			break
+		}
+	}
+
+	claims := &Claims{
+		Name:        stringClaim(source, "name"),
+		Username:    stringClaim(source, "username"),
+		Email:       stringClaim(source, "email"),
+		Role:        stringClaim(source, v.roleClaim),
+		PhoneNumber: stringClaim(source, "phoneNumber"),
+	}
+	if claims.Role == "" {
+		claims.Role = stringClaim(mapClaims, v.roleClaim)
+	}
+	if claims.Role == "" {
+		return nil, ErrMissingRole
+	}
+
+	subject := stringClaim(source, "uuid")
+	if subject == "" {
+		subject, _ = mapClaims.GetSubject()
+	}
+	if subject != "" {
+		id, err := uuid.Parse(subject)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid subject", ErrInvalidToken)
+		}
+		claims.UUID = id
+	}
+
+	return claims, nil
+}
+
+func stringClaim(claims map[string]any, key string) string {
+	for name, value := range claims {
+		if strings.EqualFold(name, key) {
+			result, _ := value.(string)
+			return result
+		}
+	}
+	return ""
+}
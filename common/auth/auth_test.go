@@ -0,0 +1,241 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const userUUID = "2b8e6c1d-5f4a-4b3c-8d2e-7f6a5b4c3d21"
+
+func generateRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func generateECKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func publicKeyPEM(t *testing.T, key any) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+// validClaims returns the claims of a customer token that expires in an hour.
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":      userUUID,
+		"username": "customer",
+		"role":     "customer",
+		"exp":      time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func newVerifier(t *testing.T, opts Options) IVerifier {
+	t.Helper()
+
+	verifier, err := NewVerifier(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return verifier
+}
+
+func TestVerifyAcceptsSignedToken(t *testing.T) {
+	rsaKey := generateRSAKey(t)
+	ecKey := generateECKey(t)
+
+	for _, tc := range []struct {
+		name   string
+		method jwt.SigningMethod
+		key    any
+		public any
+	}{
+		{name: "RS256", method: jwt.SigningMethodRS256, key: rsaKey, public: &rsaKey.PublicKey},
+		{name: "PS256", method: jwt.SigningMethodPS256, key: rsaKey, public: &rsaKey.PublicKey},
+		{name: "ES256", method: jwt.SigningMethodES256, key: ecKey, public: &ecKey.PublicKey},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			verifier := newVerifier(t, Options{PublicKey: publicKeyPEM(t, tc.public)})
+
+			claims, err := verifier.Verify(sign(t, tc.method, tc.key, "", validClaims()))
+			if err != nil {
+				t.Fatalf("expected token to verify, got %v", err)
+			}
+			if claims.UUID.String() != userUUID || claims.Username != "customer" || claims.Role != "customer" {
+				t.Errorf("unexpected claims %+v", claims)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsAlgorithmsOutsideAllowList(t *testing.T) {
+	key := generateRSAKey(t)
+	publicKey := publicKeyPEM(t, &key.PublicKey)
+	verifier := newVerifier(t, Options{PublicKey: publicKey})
+
+	for _, tc := range []struct {
+		name  string
+		token string
+	}{
+		// HS256 keyed with the public key is the classic algorithm confusion
+		{name: "HS256", token: sign(t, jwt.SigningMethodHS256, []byte(publicKey), "", validClaims())},
+		{name: "none", token: sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", validClaims())},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := verifier.Verify(tc.token)
+			if !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("expected ErrInvalidToken, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyRegisteredClaims(t *testing.T) {
+	key := generateRSAKey(t)
+	opts := Options{
+		PublicKey: publicKeyPEM(t, &key.PublicKey),
+		Issuer:    "user-service",
+		Audience:  "field-service",
+		Leeway:    30 * time.Second,
+	}
+	verifier := newVerifier(t, opts)
+
+	withClaims := func(change func(jwt.MapClaims)) jwt.MapClaims {
+		claims := validClaims()
+		claims["iss"] = "user-service"
+		claims["aud"] = "field-service"
+		change(claims)
+		return claims
+	}
+
+	for _, tc := range []struct {
+		name   string
+		claims jwt.MapClaims
+		err    error
+	}{
+		{
+			name:   "valid",
+			claims: withClaims(func(jwt.MapClaims) {}),
+		},
+		{
+			name:   "missing exp",
+			claims: withClaims(func(claims jwt.MapClaims) { delete(claims, "exp") }),
+			err:    ErrInvalidToken,
+		},
+		{
+			name:   "expired within leeway",
+			claims: withClaims(func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-10 * time.Second).Unix() }),
+		},
+		{
+			name:   "expired past leeway",
+			claims: withClaims(func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Minute).Unix() }),
+			err:    ErrInvalidToken,
+		},
+		{
+			name:   "wrong issuer",
+			claims: withClaims(func(claims jwt.MapClaims) { claims["iss"] = "someone-else" }),
+			err:    ErrInvalidToken,
+		},
+		{
+			name:   "missing issuer",
+			claims: withClaims(func(claims jwt.MapClaims) { delete(claims, "iss") }),
+			err:    ErrInvalidToken,
+		},
+		{
+			name:   "wrong audience",
+			claims: withClaims(func(claims jwt.MapClaims) { claims["aud"] = "order-service" }),
+			err:    ErrInvalidToken,
+		},
+		{
+			name:   "audience list",
+			claims: withClaims(func(claims jwt.MapClaims) { claims["aud"] = []string{"order-service", "field-service"} }),
+		},
+		{
+			name:   "missing role",
+			claims: withClaims(func(claims jwt.MapClaims) { delete(claims, "role") }),
+			err:    ErrMissingRole,
+		},
+		{
+			name:   "invalid subject",
+			claims: withClaims(func(claims jwt.MapClaims) { claims["sub"] = "not-a-uuid" }),
+			err:    ErrInvalidToken,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, key, "", tc.claims))
+			if tc.err == nil && err != nil {
+				t.Fatalf("expected token to verify, got %v", err)
+			}
+			if tc.err != nil && !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestVerifyReadsNestedUserClaims(t *testing.T) {
+	key := generateECKey(t)
+	verifier := newVerifier(t, Options{PublicKey: publicKeyPEM(t, &key.PublicKey), RoleClaim: "roleName"})
+
+	claims, err := verifier.Verify(sign(t, jwt.SigningMethodES256, key, "", jwt.MapClaims{
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"user": map[string]any{
+			"uuid":     userUUID,
+			"username": "admin",
+			"roleName": "admin",
+		},
+	}))
+	if err != nil {
+		t.Fatalf("expected token to verify, got %v", err)
+	}
+	if claims.UUID.String() != userUUID || claims.Username != "admin" || claims.Role != "admin" {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+}
+
+func TestNewVerifierRequiresKey(t *testing.T) {
+	_, err := NewVerifier(Options{})
+	if !errors.Is(err, ErrNoKeyAvailable) {
+		t.Errorf("expected ErrNoKeyAvailable, got %v", err)
+	}
+}
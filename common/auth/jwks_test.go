@@ -0,0 +1,134 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func encodeBigInt(value *big.Int, size int) string {
+	return base64.RawURLEncoding.EncodeToString(value.FillBytes(make([]byte, size)))
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) jsonWebKey {
+	return jsonWebKey{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) jsonWebKey {
+	size := (key.Curve.Params().BitSize + 7) / 8
+	return jsonWebKey{
+		Kty: "EC",
+		Kid: kid,
+		Crv: key.Curve.Params().Name,
+		X:   encodeBigInt(key.X, size),
+		Y:   encodeBigInt(key.Y, size),
+	}
+}
+
+func writeJWKS(t *testing.T, keys ...jsonWebKey) string {
+	t.Helper()
+
+	data, err := json.Marshal(jsonWebKeySet{Keys: keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	err = os.WriteFile(path, data, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestVerifySelectsJWKSKeyByKid(t *testing.T) {
+	rsaKey := generateRSAKey(t)
+	ecKey := generateECKey(t)
+	encryptionKey := rsaJWK("encryption", &generateRSAKey(t).PublicKey)
+	encryptionKey.Use = "enc"
+
+	verifier := newVerifier(t, Options{
+		JWKSFile: writeJWKS(t, rsaJWK("rsa", &rsaKey.PublicKey), ecJWK("ec", &ecKey.PublicKey), encryptionKey),
+	})
+
+	for _, tc := range []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{name: "rsa kid", token: sign(t, jwt.SigningMethodRS256, rsaKey, "rsa", validClaims())},
+		{name: "ec kid", token: sign(t, jwt.SigningMethodES256, ecKey, "ec", validClaims())},
+		{
+			name:  "kid of another key",
+			token: sign(t, jwt.SigningMethodRS256, rsaKey, "ec", validClaims()),
+			err:   ErrInvalidToken,
+		},
+		{
+			name:  "unknown kid",
+			token: sign(t, jwt.SigningMethodRS256, rsaKey, "rotated", validClaims()),
+			err:   ErrUnknownKey,
+		},
+		{
+			name:  "encryption key is skipped",
+			token: sign(t, jwt.SigningMethodRS256, rsaKey, "encryption", validClaims()),
+			err:   ErrUnknownKey,
+		},
+		{
+			name:  "missing kid with several keys",
+			token: sign(t, jwt.SigningMethodRS256, rsaKey, "", validClaims()),
+			err:   ErrUnknownKey,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := verifier.Verify(tc.token)
+			if tc.err == nil && err != nil {
+				t.Fatalf("expected token to verify, got %v", err)
+			}
+			if tc.err != nil && !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestVerifyAcceptsMissingKidWithSingleKey(t *testing.T) {
+	key := generateECKey(t)
+	verifier := newVerifier(t, Options{JWKSFile: writeJWKS(t, ecJWK("only", &key.PublicKey))})
+
+	_, err := verifier.Verify(sign(t, jwt.SigningMethodES256, key, "", validClaims()))
+	if err != nil {
+		t.Errorf("expected token to verify, got %v", err)
+	}
+}
+
+func TestParseJWKSRejectsInvalidSets(t *testing.T) {
+	for name, data := range map[string]string{
+		"not json":          `{`,
+		"no signing keys":   `{"keys": [{"kty": "RSA", "kid": "a", "use": "enc", "n": "AQAB", "e": "AQAB"}]}`,
+		"unsupported kty":   `{"keys": [{"kty": "oct", "kid": "a"}]}`,
+		"unsupported crv":   `{"keys": [{"kty": "EC", "kid": "a", "crv": "P-192", "x": "AQAB", "y": "AQAB"}]}`,
+		"missing modulus":   `{"keys": [{"kty": "RSA", "kid": "a", "e": "AQAB"}]}`,
+		"short ed25519 key": `{"keys": [{"kty": "OKP", "kid": "a", "crv": "Ed25519", "x": "AQAB"}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseJWKS([]byte(data))
+			if err == nil {
+				t.Error("expected the set to be rejected")
+			}
+		})
+	}
+}
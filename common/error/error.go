@@ -1,12 +1,13 @@
 package error
 
 import (
+	"context"
 	"errors"
+	"field-service/common/logger"
 	"fmt"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
-	"github.com/sirupsen/logrus"
 )
 
 type ValidationResponse struct {
@@ -60,7 +61,8 @@ func ErrValidationResponse(err error) (validationResponse []ValidationResponse)
 	return validationResponse
 }
 
-func WrapErr(err error) error {
-	logrus.Errorf("error %s", err)
+// WrapErr logs err with the request id and user found in ctx and returns it.
+func WrapErr(ctx context.Context, err error) error {
+	logger.FromContext(ctx).Errorf("error %s", err)
 	return err
 }
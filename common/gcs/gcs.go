@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"field-service/common/logger"
 	"fmt"
 	"io"
 	"time"
 
 	"cloud.google.com/go/storage"
-	"github.com/sirupsen/logrus"
+	"github.com/gabriel-vasile/mimetype"
 	"google.golang.org/api/option"
 )
 
@@ -22,6 +24,7 @@ import (
  * 1. ServiceAccountKeyJSON - struct untuk konfigurasi autentikasi GCS
  * 2. GCSClient - client untuk operasi GCS
  * 3. UploadFile - method untuk upload file ke GCS bucket
+ * 4. Delete, Exists, URL - method pendukung agar GCSClient memenuhi storage.IStorageClient
  */
 
 // ServiceAccountKeyJSON represents the Google Cloud Service Account key configuration
@@ -49,6 +52,9 @@ type GCSCLient struct {
 // IGCSClient interface yang mendefinisikan contract untuk operasi GCS
 type IGCSClient interface {
 	UpdloadFile(context.Context, string, []byte) (string, error) // Method untuk upload file
+	Delete(context.Context, string) error                        // Method untuk menghapus file
+	Exists(context.Context, string) (bool, error)                // Method untuk mengecek keberadaan file
+	URL(string) string                                           // Method untuk membangun URL publik file
 }
 
 // NewGCSClient factory function untuk membuat instance GCS client baru
@@ -74,7 +80,7 @@ func (g *GCSCLient) createClient(ctx context.Context) (*storage.Client, error) {
 	// Step 2: Encode service account key JSON ke dalam buffer
 	err := json.NewEncoder(reqBodyBytes).Encode(g.ServiceAccountKeyJSON)
 	if err != nil {
-		logrus.Errorf("Failed to encode service account key json: %v", err)
+		logger.FromContext(ctx).Errorf("Failed to encode service account key json: %v", err)
 		return nil, err
 	}
 
@@ -84,7 +90,7 @@ func (g *GCSCLient) createClient(ctx context.Context) (*storage.Client, error) {
 	// Step 4: Membuat GCS client dengan credentials JSON
 	client, err := storage.NewClient(ctx, option.WithCredentialsJSON(jsonByte))
 	if err != nil {
-		logrus.Errorf("Failed to create client: %v", err)
+		logger.FromContext(ctx).Errorf("Failed to create client: %v", err)
 		return nil, err
 	}
 
@@ -100,16 +106,16 @@ func (g *GCSCLient) createClient(ctx context.Context) (*storage.Client, error) {
 // - data: byte array dari file yang akan diupload
 // Return: URL publik file yang berhasil diupload, atau error jika gagal
 func (c *GCSCLient) UpdloadFile(ctx context.Context, fileName string, data []byte) (string, error) {
-	// Step 1: Konfigurasi default untuk upload
+	// Step 1: Konfigurasi untuk upload
 	var (
-		contentType      = "application/octet-stream" // Content type default untuk file binary
-		timeoutInSeconds = 60                         // Timeout 60 detik untuk operasi upload
+		contentType      = mimetype.Detect(data).String() // Content type dideteksi dari isi file
+		timeoutInSeconds = 60                             // Timeout 60 detik untuk operasi upload
 	)
 
 	// Step 2: Membuat GCS client dengan autentikasi
 	client, err := c.createClient(ctx)
 	if err != nil {
-		logrus.Errorf("Failed to create client: %v", err)
+		logger.FromContext(ctx).Errorf("Failed to create client: %v", err)
 		return "", err
 	}
 
@@ -117,7 +123,7 @@ func (c *GCSCLient) UpdloadFile(ctx context.Context, fileName string, data []byt
 	defer func(client *storage.Client) {
 		err := client.Close()
 		if err != nil {
-			logrus.Errorf("Failed to close client: %v", err)
+			logger.FromContext(ctx).Errorf("Failed to close client: %v", err)
 			return
 		}
 	}(client)
@@ -138,25 +144,79 @@ func (c *GCSCLient) UpdloadFile(ctx context.Context, fileName string, data []byt
 	// Step 7: Copy data dari buffer ke GCS object writer
 	_, err = io.Copy(writer, buffer)
 	if err != nil {
-		logrus.Errorf("failed to copy: %v", err)
+		logger.FromContext(ctx).Errorf("failed to copy: %v", err)
 		return "", err
 	}
 
 	// Step 8: Tutup writer untuk finalisasi upload
 	err = writer.Close()
 	if err != nil {
-		logrus.Errorf("failed to close: %v", err)
+		logger.FromContext(ctx).Errorf("failed to close: %v", err)
 		return "", err
 	}
 
 	// Step 9: Update metadata object dengan content type yang sesuai
 	_, err = object.Update(ctx, storage.ObjectAttrsToUpdate{ContentType: contentType})
 	if err != nil {
-		logrus.Errorf("failed to update: %v", err)
+		logger.FromContext(ctx).Errorf("failed to update: %v", err)
 		return "", err
 	}
 
 	// Step 10: Generate URL publik untuk mengakses file yang sudah diupload
-	url := fmt.Sprintf("https://storage.googleapis.com/%s/%s", c.BucketName, fileName)
-	return url, nil
+	return c.URL(fileName), nil
+}
+
+// Delete method untuk menghapus file dari bucket GCS
+// File yang sudah tidak ada dianggap berhasil dihapus
+// Parameter:
+// - ctx: context untuk operasi
+// - fileName: nama file di bucket yang akan dihapus
+// Return: error jika gagal
+func (c *GCSCLient) Delete(ctx context.Context, fileName string) error {
+	client, err := c.createClient(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("Failed to create client: %v", err)
+		return err
+	}
+	defer client.Close()
+
+	err = client.Bucket(c.BucketName).Object(fileName).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		logger.FromContext(ctx).Errorf("failed to delete: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// Exists method untuk mengecek apakah file ada di bucket GCS
+// Parameter:
+// - ctx: context untuk operasi
+// - fileName: nama file di bucket
+// Return: true jika file ada, atau error jika gagal mengecek
+func (c *GCSCLient) Exists(ctx context.Context, fileName string) (bool, error) {
+	client, err := c.createClient(ctx)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("Failed to create client: %v", err)
+		return false, err
+	}
+	defer client.Close()
+
+	_, err = client.Bucket(c.BucketName).Object(fileName).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return false, nil
+		}
+		logger.FromContext(ctx).Errorf("failed to get attrs: %v", err)
+		return false, err
+	}
+
+	return true, nil
+}
+
+// URL method untuk membangun URL publik dari sebuah file di bucket
+// Parameter: fileName - nama file di bucket
+// Return: URL publik file
+func (c *GCSCLient) URL(fileName string) string {
+	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", c.BucketName, fileName)
 }
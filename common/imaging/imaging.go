@@ -0,0 +1,147 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/gabriel-vasile/mimetype"
+	"golang.org/x/image/draw"
+)
+
+const (
+	MimeJPEG = "image/jpeg"
+	MimePNG  = "image/png"
+	MimeGIF  = "image/gif"
+
+	jpegQuality = 90
+)
+
+var (
+	ErrNotAnImage         = errors.New("file is not a supported image")
+	ErrFileTooLarge       = errors.New("file is too large")
+	ErrDimensionTooLarge  = errors.New("image dimension is too large")
+	ErrDimensionTooSmall  = errors.New("image dimension is too small")
+	supportedContentTypes = map[string]string{
+		MimeJPEG: ".jpg",
+		MimePNG:  ".png",
+		MimeGIF:  ".gif",
+	}
+)
+
+type Options struct {
+	MaxFileSize    int
+	MaxWidth       int
+	MaxHeight      int
+	MinWidth       int
+	MinHeight      int
+	ThumbnailWidth int
+}
+
+type Result struct {
+	ContentType string
+	Extension   string
+	Original    []byte
+	Thumbnail   []byte
+}
+
+// Process validates an uploaded image and returns a re-encoded copy of it
+// along with a thumbnail. Re-encoding drops every metadata block (EXIF, XMP,
+// comments), so GPS coordinates or camera serials never reach the bucket. The
+// EXIF orientation of a JPEG is applied to the pixels first, and the limits
+// are checked against the upright dimensions.
+func Process(data []byte, opts Options) (*Result, error) {
+	if opts.MaxFileSize > 0 && len(data) > opts.MaxFileSize {
+		return nil, ErrFileTooLarge
+	}
+
+	contentType := mimetype.Detect(data).String()
+	extension, ok := supportedContentTypes[contentType]
+	if !ok {
+		return nil, ErrNotAnImage
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrNotAnImage
+	}
+
+	imageOrientation := 1
+	if contentType == MimeJPEG {
+		imageOrientation = orientation(data)
+	}
+
+	width, height := config.Width, config.Height
+	if transposes(imageOrientation) {
+		width, height = height, width
+	}
+
+	if (opts.MaxWidth > 0 && width > opts.MaxWidth) ||
+		(opts.MaxHeight > 0 && height > opts.MaxHeight) {
+		return nil, ErrDimensionTooLarge
+	}
+
+	if width < opts.MinWidth || height < opts.MinHeight {
+		return nil, ErrDimensionTooSmall
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrNotAnImage
+	}
+	img = orient(img, imageOrientation)
+
+	original, err := encode(img, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnail, err := encode(resize(img, opts.ThumbnailWidth), contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		ContentType: contentType,
+		Extension:   extension,
+		Original:    original,
+		Thumbnail:   thumbnail,
+	}, nil
+}
+
+// resize scales img down to width, keeping its aspect ratio. Images that are
+// already narrow enough are returned unchanged.
+func resize(img image.Image, width int) image.Image {
+	bounds := img.Bounds()
+	if width <= 0 || bounds.Dx() <= width {
+		return img
+	}
+
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+	return dst
+}
+
+func encode(img image.Image, contentType string) ([]byte, error) {
+	buffer := new(bytes.Buffer)
+	var err error
+	switch contentType {
+	case MimeJPEG:
+		err = jpeg.Encode(buffer, img, &jpeg.Options{Quality: jpegQuality})
+	case MimePNG:
+		err = png.Encode(buffer, img)
+	case MimeGIF:
+		err = gif.Encode(buffer, img, nil)
+	default:
+		return nil, ErrNotAnImage
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
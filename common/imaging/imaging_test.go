@@ -0,0 +1,193 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// The fixtures are split into a red left half and a blue right half, so the
+// colour at the top shows which way an image was turned.
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func decode(t *testing.T, data []byte) image.Image {
+	t.Helper()
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	return img
+}
+
+func TestProcess(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		fixture         string
+		opts            Options
+		err             error
+		contentType     string
+		width, height   int
+		thumbnailWidth  int
+		thumbnailHeight int
+	}{
+		{
+			name:            "jpeg",
+			fixture:         "landscape.jpg",
+			opts:            Options{ThumbnailWidth: 20},
+			contentType:     MimeJPEG,
+			width:           80,
+			height:          40,
+			thumbnailWidth:  20,
+			thumbnailHeight: 10,
+		},
+		{
+			name:            "png",
+			fixture:         "square.png",
+			opts:            Options{ThumbnailWidth: 32},
+			contentType:     MimePNG,
+			width:           64,
+			height:          64,
+			thumbnailWidth:  32,
+			thumbnailHeight: 32,
+		},
+		{
+			name:            "gif",
+			fixture:         "banner.gif",
+			opts:            Options{ThumbnailWidth: 60},
+			contentType:     MimeGIF,
+			width:           120,
+			height:          30,
+			thumbnailWidth:  60,
+			thumbnailHeight: 15,
+		},
+		{
+			name:            "thumbnail is never scaled up",
+			fixture:         "landscape.jpg",
+			opts:            Options{ThumbnailWidth: 320},
+			contentType:     MimeJPEG,
+			width:           80,
+			height:          40,
+			thumbnailWidth:  80,
+			thumbnailHeight: 40,
+		},
+		{
+			name:            "exif orientation is applied",
+			fixture:         "orientation-6.jpg",
+			opts:            Options{ThumbnailWidth: 20},
+			contentType:     MimeJPEG,
+			width:           40,
+			height:          80,
+			thumbnailWidth:  20,
+			thumbnailHeight: 40,
+		},
+		{
+			name:    "not an image",
+			fixture: "note.txt",
+			err:     ErrNotAnImage,
+		},
+		{
+			name:    "file too large",
+			fixture: "landscape.jpg",
+			opts:    Options{MaxFileSize: 100},
+			err:     ErrFileTooLarge,
+		},
+		{
+			name:    "too wide",
+			fixture: "landscape.jpg",
+			opts:    Options{MaxWidth: 64, MaxHeight: 64},
+			err:     ErrDimensionTooLarge,
+		},
+		{
+			name:    "too tall once upright",
+			fixture: "orientation-6.jpg",
+			opts:    Options{MaxWidth: 64, MaxHeight: 64},
+			err:     ErrDimensionTooLarge,
+		},
+		{
+			name:    "too small",
+			fixture: "landscape.jpg",
+			opts:    Options{MinWidth: 64, MinHeight: 64},
+			err:     ErrDimensionTooSmall,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Process(readFixture(t, tc.fixture), tc.opts)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected %v, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.ContentType != tc.contentType {
+				t.Errorf("expected content type %s, got %s", tc.contentType, result.ContentType)
+			}
+
+			bounds := decode(t, result.Original).Bounds()
+			if bounds.Dx() != tc.width || bounds.Dy() != tc.height {
+				t.Errorf("expected original %dx%d, got %dx%d", tc.width, tc.height, bounds.Dx(), bounds.Dy())
+			}
+
+			bounds = decode(t, result.Thumbnail).Bounds()
+			if bounds.Dx() != tc.thumbnailWidth || bounds.Dy() != tc.thumbnailHeight {
+				t.Errorf("expected thumbnail %dx%d, got %dx%d",
+					tc.thumbnailWidth, tc.thumbnailHeight, bounds.Dx(), bounds.Dy())
+			}
+		})
+	}
+}
+
+func TestProcessRotatesByOrientation(t *testing.T) {
+	for _, tc := range []struct {
+		fixture  string
+		topLeft  string
+		topRight string
+	}{
+		{fixture: "landscape.jpg", topLeft: "red", topRight: "blue"},
+		{fixture: "orientation-6.jpg", topLeft: "red", topRight: "red"},
+		{fixture: "orientation-8.jpg", topLeft: "blue", topRight: "blue"},
+	} {
+		t.Run(tc.fixture, func(t *testing.T) {
+			result, err := Process(readFixture(t, tc.fixture), Options{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			img := decode(t, result.Original)
+			bounds := img.Bounds()
+			if got := colourAt(img, bounds.Dx()/4, bounds.Dy()/4); got != tc.topLeft {
+				t.Errorf("expected %s at the top left, got %s", tc.topLeft, got)
+			}
+			if got := colourAt(img, bounds.Dx()*3/4, bounds.Dy()/4); got != tc.topRight {
+				t.Errorf("expected %s at the top right, got %s", tc.topRight, got)
+			}
+
+			if bytes.Contains(result.Original, exifHeader) {
+				t.Error("expected the EXIF block to be dropped")
+			}
+		})
+	}
+}
+
+func colourAt(img image.Image, x, y int) string {
+	r, _, b, _ := img.At(x, y).RGBA()
+	if b > r {
+		return "blue"
+	}
+	return "red"
+}
@@ -0,0 +1,144 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+)
+
+const (
+	orientationTag = 0x0112
+	tiffTypeShort  = 3
+)
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// orientation reads the EXIF Orientation tag of a JPEG. It returns 1, the
+// upright orientation, when the tag is missing or cannot be read.
+func orientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	// Walk the segments up to the start of the image data looking for the
+	// APP1 segment that holds EXIF
+	offset := 2
+	for offset+4 <= len(data) {
+		if data[offset] != 0xFF {
+			return 1
+		}
+		marker := data[offset+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return 1
+		}
+
+		length := int(binary.BigEndian.Uint16(data[offset+2:]))
+		end := offset + 2 + length
+		if length < 2 || end > len(data) {
+			return 1
+		}
+
+		segment := data[offset+4 : end]
+		if marker == 0xE1 && bytes.HasPrefix(segment, exifHeader) {
+			return tiffOrientation(segment[len(exifHeader):])
+		}
+		offset = end
+	}
+
+	return 1
+}
+
+// tiffOrientation reads the Orientation tag from the first IFD of a TIFF
+// header, which is how EXIF stores it.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) != orientationTag {
+			continue
+		}
+		if order.Uint16(tiff[entry+2:]) != tiffTypeShort {
+			return 1
+		}
+
+		value := int(order.Uint16(tiff[entry+8:]))
+		if value < 1 || value > 8 {
+			return 1
+		}
+		return value
+	}
+
+	return 1
+}
+
+// transposes reports whether an orientation swaps width and height.
+func transposes(orientation int) bool {
+	return orientation >= 5
+}
+
+// orient turns img upright according to an EXIF orientation, so the image
+// still shows the right way up once re-encoding has dropped the tag.
+func orient(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	dstWidth, dstHeight := width, height
+	if transposes(orientation) {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			// Map each destination pixel back to the stored pixel it shows
+			var srcX, srcY int
+			switch orientation {
+			case 2:
+				srcX, srcY = width-1-x, y
+			case 3:
+				srcX, srcY = width-1-x, height-1-y
+			case 4:
+				srcX, srcY = x, height-1-y
+			case 5:
+				srcX, srcY = y, x
+			case 6:
+				srcX, srcY = y, height-1-x
+			case 7:
+				srcX, srcY = width-1-y, height-1-x
+			case 8:
+				srcX, srcY = width-1-y, x
+			}
+			dst.Set(x, y, img.At(bounds.Min.X+srcX, bounds.Min.Y+srcY))
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"context"
+	"field-service/constants"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithRequestID returns a copy of ctx carrying the request id.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, constants.RequestID, requestID)
+}
+
+// RequestID returns the request id stored in ctx, or "" when there is none.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(constants.RequestID).(string)
+	return requestID
+}
+
+// FromContext returns a log entry tagged with the request id, user uuid and
+// trace id found in ctx, so lines from one request can be correlated.
+func FromContext(ctx context.Context) *logrus.Entry {
+	entry := logrus.NewEntry(logrus.StandardLogger())
+	if ctx == nil {
+		return entry
+	}
+
+	if requestID := RequestID(ctx); requestID != "" {
+		entry = entry.WithField("request_id", requestID)
+	}
+	if userUUID, ok := ctx.Value(constants.UserUUID).(string); ok && userUUID != "" {
+		entry = entry.WithField("user_uuid", userUUID)
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		entry = entry.WithField("trace_id", spanContext.TraceID().String())
+	}
+	return entry
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	clientConfig "field-service/clients/config"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CircuitBreakerCollector reports the state of every registered circuit
+// breaker, read on every scrape so an expired cooldown shows as half-open.
+type CircuitBreakerCollector struct {
+	description *prometheus.Desc
+}
+
+func NewCircuitBreakerCollector() prometheus.Collector {
+	return &CircuitBreakerCollector{
+		description: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "circuit_breaker_state"),
+			"Circuit breaker state by name: 0 closed, 1 open, 2 half-open.",
+			[]string{"name"},
+			nil,
+		),
+	}
+}
+
+func (c *CircuitBreakerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.description
+}
+
+func (c *CircuitBreakerCollector) Collect(ch chan<- prometheus.Metric) {
+	for name, state := range clientConfig.BreakerStates() {
+		ch <- prometheus.MustNewConstMetric(c.description, prometheus.GaugeValue, float64(state), name)
+	}
+}
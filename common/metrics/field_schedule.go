@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"field-service/domain/dto"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const collectTimeout = 5 * time.Second
+
+// FieldScheduleCounter is the part of the field schedule repository the
+// collector needs.
+type FieldScheduleCounter interface {
+	CountByStatusForDate(context.Context, time.Time, time.Time) ([]dto.FieldScheduleStatusCount, error)
+}
+
+// FieldScheduleCollector reports today's schedules per field and status,
+// queried on every scrape so the numbers are never stale.
+type FieldScheduleCollector struct {
+	counter     FieldScheduleCounter
+	description *prometheus.Desc
+}
+
+func NewFieldScheduleCollector(counter FieldScheduleCounter) prometheus.Collector {
+	return &FieldScheduleCollector{
+		counter: counter,
+		description: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "field_schedules_today"),
+			"Field schedules for today by field and status.",
+			[]string{"field_uuid", "field_code", "status"},
+			nil,
+		),
+	}
+}
+
+func (c *FieldScheduleCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.description
+}
+
+func (c *FieldScheduleCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	counts, err := c.counter.CountByStatusForDate(ctx, today, now)
+	if err != nil {
+		logrus.Errorf("failed to collect field schedule metrics: %v", err)
+		return
+	}
+
+	for _, count := range counts {
+		ch <- prometheus.MustNewConstMetric(
+			c.description,
+			prometheus.GaugeValue,
+			float64(count.Total),
+			count.FieldUUID.String(),
+			count.FieldCode,
+			string(count.Status.GetStatusString()),
+		)
+	}
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"field-service/common/logger"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ErrInvalidFileName = errors.New("invalid file name")
+
+// LocalClient stores objects on the local disk. The files are served by the
+// static route registered for RoutePath, which makes it usable on a developer
+// machine or in CI without any cloud credentials.
+type LocalClient struct {
+	Directory string
+	BaseURL   string
+	RoutePath string
+}
+
+func NewLocalClient(directory, baseURL, routePath string) IStorageClient {
+	return &LocalClient{
+		Directory: directory,
+		BaseURL:   strings.TrimSuffix(baseURL, "/"),
+		RoutePath: "/" + strings.Trim(routePath, "/"),
+	}
+}
+
+// path resolves fileName inside Directory and rejects names that would
+// escape it.
+func (l *LocalClient) path(fileName string) (string, error) {
+	cleaned := filepath.Clean("/" + fileName)
+	if cleaned == "/" {
+		return "", ErrInvalidFileName
+	}
+
+	return filepath.Join(l.Directory, filepath.FromSlash(cleaned)), nil
+}
+
+func (l *LocalClient) UpdloadFile(ctx context.Context, fileName string, data []byte) (string, error) {
+	path, err := l.path(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("failed to create directory: %v", err)
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		logger.FromContext(ctx).Errorf("failed to create temp file: %v", err)
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		logger.FromContext(ctx).Errorf("failed to write file: %v", err)
+		return "", err
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("failed to rename file: %v", err)
+		return "", err
+	}
+
+	return l.URL(fileName), nil
+}
+
+func (l *LocalClient) Delete(ctx context.Context, fileName string) error {
+	path, err := l.path(fileName)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logger.FromContext(ctx).Errorf("failed to delete file: %v", err)
+		return err
+	}
+
+	return nil
+}
+
+func (l *LocalClient) Exists(_ context.Context, fileName string) (bool, error) {
+	path, err := l.path(fileName)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (l *LocalClient) URL(fileName string) string {
+	return fmt.Sprintf("%s%s/%s", l.BaseURL, l.RoutePath, strings.TrimPrefix(fileName, "/"))
+}
@@ -0,0 +1,21 @@
+package storage
+
+import "context"
+
+const (
+	BackendGCS   = "gcs"
+	BackendLocal = "local"
+)
+
+// IStorageClient is the contract every object storage backend implements.
+type IStorageClient interface {
+	// UpdloadFile stores data under fileName and returns its public URL.
+	UpdloadFile(context.Context, string, []byte) (string, error)
+	// Delete removes fileName. Deleting a missing object is not an error.
+	Delete(context.Context, string) error
+	// Exists reports whether fileName is stored.
+	Exists(context.Context, string) (bool, error)
+	// URL builds the public URL of fileName. URL("") is the prefix shared by
+	// every object of the backend.
+	URL(string) string
+}
@@ -0,0 +1,102 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	spanKey   = "tracing:span"
+	parentKey = "tracing:parent"
+)
+
+// GormPlugin wraps every gorm statement in a span that is a child of the
+// span in the statement context, so queries run with WithContext(ctx) show
+// up under the request that issued them.
+type GormPlugin struct{}
+
+func NewGormPlugin() gorm.Plugin {
+	return &GormPlugin{}
+}
+
+func (p *GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("gorm:create").Register("tracing:before_create", start("create")),
+		callback.Create().After("gorm:create").Register("tracing:after_create", end),
+		callback.Query().Before("gorm:query").Register("tracing:before_query", start("query")),
+		callback.Query().After("gorm:query").Register("tracing:after_query", end),
+		callback.Update().Before("gorm:update").Register("tracing:before_update", start("update")),
+		callback.Update().After("gorm:update").Register("tracing:after_update", end),
+		callback.Delete().Before("gorm:delete").Register("tracing:before_delete", start("delete")),
+		callback.Delete().After("gorm:delete").Register("tracing:after_delete", end),
+		callback.Row().Before("gorm:row").Register("tracing:before_row", start("row")),
+		callback.Row().After("gorm:row").Register("tracing:after_row", end),
+		callback.Raw().Before("gorm:raw").Register("tracing:before_raw", start("raw")),
+		callback.Raw().After("gorm:raw").Register("tracing:after_raw", end),
+	)
+}
+
+func start(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement.Context == nil {
+			return
+		}
+
+		name := "gorm." + operation
+		if db.Statement.Table != "" {
+			name += " " + db.Statement.Table
+		}
+
+		ctx, span := Tracer().Start(db.Statement.Context, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemPostgreSQL),
+		)
+		db.InstanceSet(parentKey, db.Statement.Context)
+		db.InstanceSet(spanKey, span)
+		db.Statement.Context = ctx
+	}
+}
+
+func end(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	// Restore the caller's context so a reused statement does not nest its
+	// next span under this one
+	if parent, ok := db.InstanceGet(parentKey); ok {
+		db.Statement.Context = parent.(context.Context)
+	}
+
+	// Only the statement with placeholders is recorded, never the values
+	span.SetAttributes(
+		semconv.DBQueryText(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+	if db.Statement.Table != "" {
+		span.SetAttributes(semconv.DBCollectionName(db.Statement.Table))
+	}
+
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+}
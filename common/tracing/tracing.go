@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+
+	instrumentationName = "field-service"
+)
+
+type Options struct {
+	ServiceName string
+	Environment string
+	Exporter    string
+	// Endpoint is the OTLP/HTTP collector, as host:port or a full URL.
+	Endpoint    string
+	Insecure    bool
+	SampleRatio float64
+}
+
+// ShutdownFunc flushes the spans still buffered and stops the exporter.
+type ShutdownFunc func(context.Context) error
+
+// Propagate installs the W3C trace context and baggage propagators. It is
+// enough on its own to pass an incoming traceparent on to outgoing calls
+// while tracing is disabled.
+func Propagate() {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+}
+
+// Init installs a tracer provider exporting to options.Exporter. Sampling
+// follows the caller's decision when a traceparent is present.
+func Init(ctx context.Context, options Options) (ShutdownFunc, error) {
+	Propagate()
+
+	exporter, err := newExporter(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			semconv.ServiceName(options.ServiceName),
+			semconv.DeploymentEnvironment(options.Environment),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(options.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, options Options) (sdktrace.SpanExporter, error) {
+	switch options.Exporter {
+	case ExporterStdout:
+		return stdouttrace.New()
+	case ExporterOTLP:
+		exporterOptions := make([]otlptracehttp.Option, 0)
+		if strings.Contains(options.Endpoint, "://") {
+			exporterOptions = append(exporterOptions, otlptracehttp.WithEndpointURL(options.Endpoint))
+		} else {
+			exporterOptions = append(exporterOptions, otlptracehttp.WithEndpoint(options.Endpoint))
+		}
+		if options.Insecure {
+			exporterOptions = append(exporterOptions, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, exporterOptions...)
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", options.Exporter)
+	}
+}
+
+// Tracer returns the tracer the service creates its spans with.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Inject writes the trace context of ctx into headers.
+func Inject(ctx context.Context, set func(key, value string)) {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	for key, value := range carrier {
+		set(key, value)
+	}
+}
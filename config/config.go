@@ -1,36 +1,47 @@
 package config
 
 import (
-	"field-service/common/utils"
-	"os"
-
-	"github.com/sirupsen/logrus"
-	_ "github.com/spf13/viper/remote"
+	"context"
 )
 
 var Config AppConfig
 
+// loadedFromConsul is set when Init read a Consul key, the only source the
+// watcher reloads from.
+var loadedFromConsul bool
+
 type AppConfig struct {
-	Port                       int             `json:"port"`
-	AppName                    string          `json:"appName"`
-	AppEnv                     string          `json:"appEnv"`
-	SignatureKey               string          `json:"signatureKey"`
-	Database                   DatabaseConfig  `json:"database"`
-	RateLimiterRequest         int             `json:"rateLimiterRequest"`
-	RateLimiterTimeSecond      int             `json:"rateLimiterTimeSecond"`
-	InternalService            InternalService `json:"internalService"`
-	GCSType                    string          `json:"gcsType"`
-	GCSProjectID               string          `json:"gcsProjectID"`
-	GCSPrivateKeyID            string          `json:"gcsPrivateKeyID"`
-	GCSPrivateKey              string          `json:"gcsPrivateKey"`
-	GCSClientEmail             string          `json:"gcsClientEmail"`
-	GCSClientID                string          `json:"gcsClientID"`
-	GCSAuthURI                 string          `json:"gcsAuthURI"`
-	GCSTokenURI                string          `json:"gcsTokenURI"`
-	GCSAuthProviderX509CertURL string          `json:"gcsAuthProviderX509CertURL"`
-	GCSClientX509CertURL       string          `json:"gcsClientX509CertURL"`
-	GCSUniverseDomain          string          `json:"gcsUniverseDomain"`
-	GCSBucketName              string          `json:"gcsBucketName"`
+	Port                       int                    `json:"port"`
+	AppName                    string                 `json:"appName"`
+	AppEnv                     string                 `json:"appEnv"`
+	SignatureKey               string                 `json:"signatureKey"`
+	SignatureSkewSecond        int                    `json:"signatureSkewSecond"`
+	ServiceAuth                map[string]ServiceAuth `json:"serviceAuth"`
+	Database                   DatabaseConfig         `json:"database"`
+	RateLimiterRequest         int                    `json:"rateLimiterRequest"`
+	RateLimiterTimeSecond      int                    `json:"rateLimiterTimeSecond"`
+	InternalService            InternalService        `json:"internalService"`
+	GCSType                    string                 `json:"gcsType"`
+	GCSProjectID               string                 `json:"gcsProjectID"`
+	GCSPrivateKeyID            string                 `json:"gcsPrivateKeyID"`
+	GCSPrivateKey              string                 `json:"gcsPrivateKey"`
+	GCSClientEmail             string                 `json:"gcsClientEmail"`
+	GCSClientID                string                 `json:"gcsClientID"`
+	GCSAuthURI                 string                 `json:"gcsAuthURI"`
+	GCSTokenURI                string                 `json:"gcsTokenURI"`
+	GCSAuthProviderX509CertURL string                 `json:"gcsAuthProviderX509CertURL"`
+	GCSClientX509CertURL       string                 `json:"gcsClientX509CertURL"`
+	GCSUniverseDomain          string                 `json:"gcsUniverseDomain"`
+	GCSBucketName              string                 `json:"gcsBucketName"`
+	StorageBackend             string                 `json:"storageBackend"`
+	LocalStorage               LocalStorage           `json:"localStorage"`
+	FieldScheduleHoldMinutes   int                    `json:"fieldScheduleHoldMinutes"`
+	HoldSweeperIntervalSecond  int                    `json:"holdSweeperIntervalSecond"`
+	JWT                        JWTConfig              `json:"jwt"`
+	UserCache                  UserCache              `json:"userCache"`
+	NATS                       NATSConfig             `json:"nats"`
+	Metrics                    MetricsConfig          `json:"metrics"`
+	Tracing                    TracingConfig          `json:"tracing"`
 }
 
 type DatabaseConfig struct {
@@ -46,22 +57,98 @@ type DatabaseConfig struct {
 	MaxIdleTime           int    `json:"maxIdleTime"`
 }
 
+type LocalStorage struct {
+	Directory string `json:"directory"`
+	BaseURL   string `json:"baseURL"`
+	RoutePath string `json:"routePath"`
+}
+
+type JWTConfig struct {
+	PublicKey      string `json:"publicKey"`
+	PublicKeyFile  string `json:"publicKeyFile"`
+	JWKSFile       string `json:"jwksFile"`
+	Issuer         string `json:"issuer"`
+	Audience       string `json:"audience"`
+	RoleClaim      string `json:"roleClaim"`
+	LeewaySecond   int    `json:"leewaySecond"`
+	RemoteFallback bool   `json:"remoteFallback"`
+}
+
+type UserCache struct {
+	TTLSecond         int `json:"ttlSecond"`
+	NegativeTTLSecond int `json:"negativeTTLSecond"`
+	MaxSize           int `json:"maxSize"`
+}
+
+// ServiceAuth lists the signature keys a calling service may sign with and
+// the internal routes it may call, written as "METHOD /api/v1/path" or "*".
+// Routes only restrict the internal, token-less endpoints. Once serviceAuth is
+// set, unlisted services are rejected everywhere, so callers of the
+// token-authenticated API must be listed too, with keys and no routes.
+type ServiceAuth struct {
+	Keys   []string `json:"keys"`
+	Routes []string `json:"routes"`
+}
+
+type NATSConfig struct {
+	URL                       string `json:"url"`
+	OutboxRelayIntervalSecond int    `json:"outboxRelayIntervalSecond"`
+	OutboxBatchSize           int    `json:"outboxBatchSize"`
+	OutboxRetentionHour       int    `json:"outboxRetentionHour"`
+	QueueGroup                string `json:"queueGroup"`
+	Stream                    string `json:"stream"`
+	MaxDeliver                int    `json:"maxDeliver"`
+	RetryDelaySecond          int    `json:"retryDelaySecond"`
+	OrderPaidSubject          string `json:"orderPaidSubject"`
+	OrderCancelledSubject     string `json:"orderCancelledSubject"`
+	OrderExpiredSubject       string `json:"orderExpiredSubject"`
+}
+
+// MetricsConfig controls the Prometheus endpoint. With authEnabled, scrapers
+// must send the token as a bearer token.
+type MetricsConfig struct {
+	Enabled     bool   `json:"enabled"`
+	Path        string `json:"path"`
+	AuthEnabled bool   `json:"authEnabled"`
+	Token       string `json:"token"`
+}
+
+// TracingConfig controls OpenTelemetry tracing. Spans go to stdout or to an
+// OTLP/HTTP collector at endpoint. sampleRatio applies to new traces only,
+// requests carrying a traceparent keep the caller's decision.
+type TracingConfig struct {
+	Enabled     bool    `json:"enabled"`
+	Exporter    string  `json:"exporter"`
+	Endpoint    string  `json:"endpoint"`
+	Insecure    bool    `json:"insecure"`
+	SampleRatio float64 `json:"sampleRatio"`
+}
+
 type InternalService struct {
 	User User `json:"user"`
 }
 
 type User struct {
-	Host         string `json:"host"`
-	SignatureKey string `json:"signatureKey"`
+	Host                      string `json:"host"`
+	SignatureKey              string `json:"signatureKey"`
+	TimeoutSecond             int    `json:"timeoutSecond"`
+	RetryMaxAttempts          int    `json:"retryMaxAttempts"`
+	RetryBaseDelayMillisecond int    `json:"retryBaseDelayMillisecond"`
+	RetryMaxDelayMillisecond  int    `json:"retryMaxDelayMillisecond"`
+	BreakerThreshold          int    `json:"breakerThreshold"`
+	BreakerCooldownSecond     int    `json:"breakerCooldownSecond"`
 }
 
+// Init loads and validates the config, panicking with every problem found.
 func Init() {
-	err := utils.BindFromJSON(&Config, "config.json", ".")
+	cfg, fromConsul, err := Load(context.Background())
 	if err != nil {
-		logrus.Infof("Failed load config json local %v", err)
-		err = utils.BindFromConsul(&Config, os.Getenv("CONSUL_HTPP_URL"), os.Getenv("CONSUL_CONFIG_KEY"))
-		if err != nil {
-			panic(err)
-		}
+		panic(err)
 	}
+
+	Config = *cfg
+	loadedFromConsul = fromConsul
+
+	loaded := Config
+	current.Store(&loaded)
 }
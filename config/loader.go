@@ -0,0 +1,453 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"field-service/common/storage"
+	"field-service/common/tracing"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	configFile = "config.json"
+
+	// EnvPrefix prefixes the environment variables that override the config,
+	// e.g. FIELD_SERVICE_DATABASE_HOST for database.host.
+	EnvPrefix = "FIELD_SERVICE_"
+
+	consulRequestTimeout = 10 * time.Second
+
+	redacted = "********"
+)
+
+var ErrInvalidConfig = errors.New("invalid config")
+
+// Defaults returns the config every layer is applied on top of.
+func Defaults() AppConfig {
+	return AppConfig{
+		Port:                8002,
+		AppName:             "field-service",
+		AppEnv:              "local",
+		SignatureSkewSecond: 300,
+		Database: DatabaseConfig{
+			Host:                  "localhost",
+			Port:                  5432,
+			MaxOpenConnection:     10,
+			MaxLifetimeConnection: 10,
+			MaxIdleConnection:     10,
+			MaxIdleTime:           10,
+		},
+		RateLimiterRequest:    1000,
+		RateLimiterTimeSecond: 60,
+		InternalService: InternalService{
+			User: User{
+				TimeoutSecond:             5,
+				RetryMaxAttempts:          3,
+				RetryBaseDelayMillisecond: 100,
+				RetryMaxDelayMillisecond:  2000,
+				BreakerThreshold:          5,
+				BreakerCooldownSecond:     30,
+			},
+		},
+		StorageBackend: storage.BackendGCS,
+		LocalStorage: LocalStorage{
+			Directory: "./storage",
+			RoutePath: "/storage",
+		},
+		FieldScheduleHoldMinutes:  15,
+		HoldSweeperIntervalSecond: 60,
+		JWT: JWTConfig{
+			RoleClaim:    "role",
+			LeewaySecond: 30,
+		},
+		UserCache: UserCache{
+			TTLSecond:         300,
+			NegativeTTLSecond: 10,
+			MaxSize:           10000,
+		},
+		NATS: NATSConfig{
+			OutboxRelayIntervalSecond: 5,
+			OutboxBatchSize:           100,
+			OutboxRetentionHour:       72,
+			QueueGroup:                "field-service",
+			Stream:                    "ORDERS",
+			MaxDeliver:                10,
+			RetryDelaySecond:          5,
+			OrderPaidSubject:          "order.paid",
+			OrderCancelledSubject:     "order.cancelled",
+			OrderExpiredSubject:       "order.expired",
+		},
+		Metrics: MetricsConfig{
+			Path:        "/metrics",
+			AuthEnabled: true,
+		},
+		Tracing: TracingConfig{
+			Exporter:    tracing.ExporterStdout,
+			SampleRatio: 1,
+		},
+	}
+}
+
+// Load builds the effective config from, in order of precedence, the
+// FIELD_SERVICE_* environment, the Consul key, config.json and Defaults.
+// The returned config is usable for inspection even when validation fails.
+func Load(ctx context.Context) (*AppConfig, bool, error) {
+	var consulData []byte
+	kv := newConsulKVFromEnv()
+	if kv != nil {
+		data, err := kv.get(ctx)
+		if err != nil {
+			return nil, false, fmt.Errorf("read consul config: %w", err)
+		}
+		consulData = data
+	}
+
+	cfg, err := build(consulData)
+	return cfg, consulData != nil, err
+}
+
+// build layers config.json, consulData and the environment on top of the
+// defaults and validates the result.
+func build(consulData []byte) (*AppConfig, error) {
+	cfg := Defaults()
+
+	fileData, err := os.ReadFile(configFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	for _, layer := range []struct {
+		name string
+		data []byte
+	}{
+		{name: configFile, data: fileData},
+		{name: "consul", data: consulData},
+	} {
+		if layer.data == nil {
+			continue
+		}
+
+		err = applyJSON(&cfg, layer.data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidConfig, layer.name, err)
+		}
+	}
+
+	err = applyEnv(&cfg, os.Environ())
+	return &cfg, errors.Join(err, cfg.Validate())
+}
+
+// applyJSON overlays the keys present in data. The old rateLimiterMaxRequest
+// key from earlier config examples is still honoured.
+func applyJSON(cfg *AppConfig, data []byte) error {
+	err := json.Unmarshal(data, cfg)
+	if err != nil {
+		return err
+	}
+
+	var legacy struct {
+		RateLimiterRequest    *int `json:"rateLimiterRequest"`
+		RateLimiterMaxRequest *int `json:"rateLimiterMaxRequest"`
+	}
+	err = json.Unmarshal(data, &legacy)
+	if err != nil {
+		return err
+	}
+
+	if legacy.RateLimiterMaxRequest != nil && legacy.RateLimiterRequest == nil {
+		logrus.Warn("rateLimiterMaxRequest is deprecated, use rateLimiterRequest")
+		cfg.RateLimiterRequest = *legacy.RateLimiterMaxRequest
+	}
+	return nil
+}
+
+// applyEnv overrides fields from FIELD_SERVICE_* variables named after the
+// upper snake case json path, e.g. FIELD_SERVICE_INTERNAL_SERVICE_USER_HOST.
+// Lists take comma separated values, maps take JSON.
+func applyEnv(cfg *AppConfig, environ []string) error {
+	values := make(map[string]string)
+	for _, entry := range environ {
+		name, value, ok := strings.Cut(entry, "=")
+		if ok && strings.HasPrefix(name, EnvPrefix) {
+			values[strings.TrimPrefix(name, EnvPrefix)] = value
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	problems := make([]error, 0)
+	walkFields(reflect.ValueOf(cfg).Elem(), "", func(name string, field reflect.Value) {
+		value, ok := values[name]
+		if !ok {
+			return
+		}
+
+		err := setField(field, value)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("%s%s: %v", EnvPrefix, name, err))
+		}
+	})
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidConfig, errors.Join(problems...))
+	}
+	return nil
+}
+
+// walkFields calls fn for every leaf field with its environment name.
+// Nested structs are descended into, everything else is a leaf.
+func walkFields(value reflect.Value, prefix string, fn func(string, reflect.Value)) {
+	for i := 0; i < value.NumField(); i++ {
+		tag := strings.Split(value.Type().Field(i).Tag.Get("json"), ",")[0]
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		name := prefix + envName(tag)
+		field := value.Field(i)
+		if field.Kind() == reflect.Struct {
+			walkFields(field, name+"_", fn)
+			continue
+		}
+		fn(name, field)
+	}
+}
+
+// envName turns a camelCase json key into UPPER_SNAKE_CASE, keeping
+// acronyms together: negativeTTLSecond becomes NEGATIVE_TTL_SECOND.
+func envName(key string) string {
+	runes := []rune(key)
+	var builder strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(previous) || nextIsLower {
+				builder.WriteByte('_')
+			}
+		}
+		builder.WriteRune(unicode.ToUpper(r))
+	}
+	return builder.String()
+}
+
+func setField(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int:
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(number))
+	case reflect.Bool:
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(boolean)
+	case reflect.Float64:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(number)
+	case reflect.Slice:
+		if strings.HasPrefix(strings.TrimSpace(value), "[") {
+			return json.Unmarshal([]byte(value), field.Addr().Interface())
+		}
+		items := strings.Split(value, ",")
+		for i := range items {
+			items[i] = strings.TrimSpace(items[i])
+		}
+		field.Set(reflect.ValueOf(items))
+	case reflect.Map:
+		fresh := reflect.New(field.Type())
+		err := json.Unmarshal([]byte(value), fresh.Interface())
+		if err != nil {
+			return err
+		}
+		field.Set(fresh.Elem())
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+	return nil
+}
+
+// Validate checks required values and ranges and reports every problem at
+// once.
+func (c *AppConfig) Validate() error {
+	problems := make([]string, 0)
+	require := func(ok bool, problem string) {
+		if !ok {
+			problems = append(problems, problem)
+		}
+	}
+
+	require(c.Port > 0 && c.Port <= 65535, "port must be between 1 and 65535")
+	require(c.AppName != "", "appName is required")
+	require(c.SignatureKey != "" || len(c.ServiceAuth) > 0, "signatureKey or serviceAuth is required")
+	require(c.SignatureSkewSecond >= 0, "signatureSkewSecond must not be negative")
+	require(c.Database.Host != "", "database.host is required")
+	require(c.Database.Port > 0 && c.Database.Port <= 65535, "database.port must be between 1 and 65535")
+	require(c.Database.Name != "", "database.name is required")
+	require(c.Database.Username != "", "database.username is required")
+	require(c.Database.MaxOpenConnection > 0, "database.maxOpenConnection must be positive")
+	require(c.Database.MaxIdleConnection >= 0, "database.maxIdleConnection must not be negative")
+	require(c.RateLimiterRequest > 0, "rateLimiterRequest must be positive")
+	require(c.RateLimiterTimeSecond > 0, "rateLimiterTimeSecond must be positive")
+	require(c.InternalService.User.Host != "", "internalService.user.host is required")
+	require(c.InternalService.User.TimeoutSecond >= 0, "internalService.user.timeoutSecond must not be negative")
+	require(c.InternalService.User.RetryMaxAttempts >= 0, "internalService.user.retryMaxAttempts must not be negative")
+	require(
+		c.StorageBackend == storage.BackendGCS || c.StorageBackend == storage.BackendLocal,
+		fmt.Sprintf("storageBackend must be %q or %q", storage.BackendGCS, storage.BackendLocal),
+	)
+	if c.StorageBackend == storage.BackendGCS {
+		require(c.GCSBucketName != "", "gcsBucketName is required for the gcs storage backend")
+	}
+	if c.StorageBackend == storage.BackendLocal {
+		require(c.LocalStorage.Directory != "", "localStorage.directory is required for the local storage backend")
+	}
+	require(c.FieldScheduleHoldMinutes > 0, "fieldScheduleHoldMinutes must be positive")
+	require(c.HoldSweeperIntervalSecond > 0, "holdSweeperIntervalSecond must be positive")
+	require(c.JWT.LeewaySecond >= 0, "jwt.leewaySecond must not be negative")
+	require(c.UserCache.TTLSecond >= 0, "userCache.ttlSecond must not be negative")
+	require(c.UserCache.MaxSize >= 0, "userCache.maxSize must not be negative")
+	if c.NATS.URL != "" {
+		require(c.NATS.OutboxRelayIntervalSecond > 0, "nats.outboxRelayIntervalSecond must be positive")
+		require(c.NATS.OutboxBatchSize > 0, "nats.outboxBatchSize must be positive")
+		require(c.NATS.OutboxRetentionHour > 0, "nats.outboxRetentionHour must be positive")
+		require(c.NATS.Stream != "", "nats.stream is required")
+		require(c.NATS.MaxDeliver > 0, "nats.maxDeliver must be positive")
+		require(c.NATS.RetryDelaySecond > 0, "nats.retryDelaySecond must be positive")
+	}
+
+	if c.Metrics.Enabled {
+		require(strings.HasPrefix(c.Metrics.Path, "/"), "metrics.path must start with /")
+		require(!c.Metrics.AuthEnabled || c.Metrics.Token != "", "metrics.token is required when metrics.authEnabled is on")
+	}
+
+	if c.Tracing.Enabled {
+		require(
+			c.Tracing.Exporter == tracing.ExporterStdout || c.Tracing.Exporter == tracing.ExporterOTLP,
+			fmt.Sprintf("tracing.exporter must be %q or %q", tracing.ExporterStdout, tracing.ExporterOTLP),
+		)
+		if c.Tracing.Exporter == tracing.ExporterOTLP {
+			require(c.Tracing.Endpoint != "", "tracing.endpoint is required for the otlp exporter")
+		}
+		require(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sampleRatio must be between 0 and 1")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w:\n  - %s", ErrInvalidConfig, strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// Redacted returns a copy with secrets masked, safe to print or log.
+func (c AppConfig) Redacted() AppConfig {
+	mask := func(value string) string {
+		if value == "" {
+			return ""
+		}
+		return redacted
+	}
+
+	c.SignatureKey = mask(c.SignatureKey)
+	c.Database.Password = mask(c.Database.Password)
+	c.InternalService.User.SignatureKey = mask(c.InternalService.User.SignatureKey)
+	c.GCSPrivateKeyID = mask(c.GCSPrivateKeyID)
+	c.GCSPrivateKey = mask(c.GCSPrivateKey)
+	c.Metrics.Token = mask(c.Metrics.Token)
+
+	serviceAuth := make(map[string]ServiceAuth, len(c.ServiceAuth))
+	for name, auth := range c.ServiceAuth {
+		keys := make([]string, len(auth.Keys))
+		for i, key := range auth.Keys {
+			keys[i] = mask(key)
+		}
+		serviceAuth[name] = ServiceAuth{Keys: keys, Routes: auth.Routes}
+	}
+	c.ServiceAuth = serviceAuth
+
+	return c
+}
+
+// consulKV reads a single Consul KV key over the HTTP API.
+type consulKV struct {
+	client *http.Client
+	url    string
+	token  string
+}
+
+func newConsulKV(address, key, token string) *consulKV {
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+
+	return &consulKV{
+		client: &http.Client{Timeout: consulRequestTimeout},
+		url:    fmt.Sprintf("%s/v1/kv/%s?raw", strings.TrimRight(address, "/"), url.PathEscape(key)),
+		token:  token,
+	}
+}
+
+// newConsulKVFromEnv returns nil when CONSUL_HTPP_URL or the key is unset.
+func newConsulKVFromEnv() *consulKV {
+	address := os.Getenv("CONSUL_HTPP_URL")
+	key := consulKey()
+	if address == "" || key == "" {
+		return nil
+	}
+
+	return newConsulKV(address, key, os.Getenv("CONSUL_HTPP_TOKEN"))
+}
+
+func consulKey() string {
+	key := os.Getenv("CONSUL_CONFIG_KEY")
+	if key == "" {
+		key = os.Getenv("CONSUL_HTPP_KEY")
+	}
+	return key
+}
+
+func (c *consulKV) get(ctx context.Context) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		request.Header.Set("X-Consul-Token", c.token)
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("consul responded with status %d", response.StatusCode)
+	}
+	return body, nil
+}
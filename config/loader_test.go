@@ -0,0 +1,84 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEnvName(t *testing.T) {
+	for key, expected := range map[string]string{
+		"port":                       "PORT",
+		"rateLimiterRequest":         "RATE_LIMITER_REQUEST",
+		"negativeTTLSecond":          "NEGATIVE_TTL_SECOND",
+		"gcsProjectID":               "GCS_PROJECT_ID",
+		"gcsAuthProviderX509CertURL": "GCS_AUTH_PROVIDER_X509_CERT_URL",
+		"baseURL":                    "BASE_URL",
+	} {
+		if actual := envName(key); actual != expected {
+			t.Errorf("envName(%q) = %q, expected %q", key, actual, expected)
+		}
+	}
+}
+
+func TestLayers(t *testing.T) {
+	cfg := Defaults()
+
+	err := applyJSON(&cfg, []byte(`{"rateLimiterMaxRequest": 50, "database": {"name": "field"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.RateLimiterRequest != 50 {
+		t.Errorf("expected legacy rateLimiterMaxRequest to apply, got %d", cfg.RateLimiterRequest)
+	}
+	if cfg.Database.Port != 5432 || cfg.Database.Name != "field" {
+		t.Errorf("expected nested defaults to be kept, got %+v", cfg.Database)
+	}
+
+	err = applyEnv(&cfg, []string{
+		"FIELD_SERVICE_PORT=9000",
+		"FIELD_SERVICE_DATABASE_PASSWORD=secret",
+		"FIELD_SERVICE_JWT_REMOTE_FALLBACK=true",
+		`FIELD_SERVICE_SERVICE_AUTH={"order-service": {"keys": ["k1"], "routes": ["*"]}}`,
+		"UNRELATED=1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Port != 9000 || cfg.Database.Password != "secret" || !cfg.JWT.RemoteFallback {
+		t.Errorf("expected environment overrides, got %+v", cfg)
+	}
+	if cfg.ServiceAuth["order-service"].Keys[0] != "k1" {
+		t.Errorf("expected service auth from environment, got %+v", cfg.ServiceAuth)
+	}
+
+	redacted := cfg.Redacted()
+	if redacted.Database.Password == "secret" || redacted.ServiceAuth["order-service"].Keys[0] == "k1" {
+		t.Error("expected secrets to be masked")
+	}
+	if cfg.ServiceAuth["order-service"].Keys[0] != "k1" {
+		t.Error("expected Redacted to leave the original untouched")
+	}
+
+	err = applyEnv(&cfg, []string{"FIELD_SERVICE_PORT=abc"})
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("expected invalid number to be reported, got %v", err)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	cfg := Defaults()
+	cfg.Port = 0
+	cfg.RateLimiterRequest = 0
+
+	err := cfg.Validate()
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got %v", err)
+	}
+
+	for _, problem := range []string{"port", "rateLimiterRequest", "database.name", "internalService.user.host", "gcsBucketName"} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("expected %q in %v", problem, err)
+		}
+	}
+}
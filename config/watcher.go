@@ -0,0 +1,160 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	current atomic.Pointer[AppConfig]
+
+	subscribersMutex sync.Mutex
+	subscribers      []Subscriber
+)
+
+// Subscriber is called after a reloaded config has been swapped in.
+type Subscriber func(previous, next *AppConfig)
+
+// Current returns the latest config. Values that can change at runtime
+// should be read through Current instead of Config, which keeps the
+// config the service started with.
+func Current() *AppConfig {
+	cfg := current.Load()
+	if cfg == nil {
+		return &Config
+	}
+	return cfg
+}
+
+// Subscribe registers fn to be notified of every reload.
+func Subscribe(fn Subscriber) {
+	subscribersMutex.Lock()
+	defer subscribersMutex.Unlock()
+
+	subscribers = append(subscribers, fn)
+}
+
+// swap stores next as the current config and notifies the subscribers.
+func swap(next *AppConfig) {
+	previous := Current()
+	current.Store(next)
+
+	subscribersMutex.Lock()
+	notify := make([]Subscriber, len(subscribers))
+	copy(notify, subscribers)
+	subscribersMutex.Unlock()
+
+	for _, fn := range notify {
+		fn(previous, next)
+	}
+}
+
+// restartRequired lists the changed settings that are only read at startup.
+func restartRequired(previous, next *AppConfig) []string {
+	changed := make([]string, 0)
+	if previous.Port != next.Port {
+		changed = append(changed, "port")
+	}
+	if previous.Database != next.Database {
+		changed = append(changed, "database")
+	}
+	if previous.NATS != next.NATS {
+		changed = append(changed, "nats")
+	}
+	if previous.StorageBackend != next.StorageBackend || previous.LocalStorage != next.LocalStorage {
+		changed = append(changed, "storage")
+	}
+	if previous.JWT != next.JWT {
+		changed = append(changed, "jwt")
+	}
+	if previous.Tracing != next.Tracing {
+		changed = append(changed, "tracing")
+	}
+	return changed
+}
+
+// Watcher polls a Consul KV key and swaps in the config built from it when
+// it changes. Updates that fail to parse or validate are logged and ignored.
+type Watcher struct {
+	kv       *consulKV
+	interval time.Duration
+	last     []byte
+}
+
+func NewWatcher(address, key, token string, interval time.Duration) *Watcher {
+	return &Watcher{
+		kv:       newConsulKV(address, key, token),
+		interval: interval,
+	}
+}
+
+// NewWatcherFromEnv builds a watcher from the CONSUL_* environment variables.
+// It returns nil when the config was not loaded from Consul or watching is
+// disabled.
+func NewWatcherFromEnv() *Watcher {
+	if !loadedFromConsul {
+		return nil
+	}
+
+	kv := newConsulKVFromEnv()
+	seconds, _ := strconv.Atoi(os.Getenv("CONSUL_WATCH_INTERNAL_SECONDS"))
+	if kv == nil || seconds <= 0 {
+		return nil
+	}
+
+	return &Watcher{kv: kv, interval: time.Duration(seconds) * time.Second}
+}
+
+// Run polls until ctx is cancelled.
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := w.Poll(ctx)
+			if err != nil {
+				logrus.Errorf("failed to reload config from consul: %v", err)
+			}
+		}
+	}
+}
+
+// Poll fetches the key once and reports whether a new config was applied.
+func (w *Watcher) Poll(ctx context.Context) (bool, error) {
+	raw, err := w.kv.get(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if w.last != nil && bytes.Equal(raw, w.last) {
+		return false, nil
+	}
+
+	// Rebuild every layer so environment overrides keep winning over Consul
+	next, err := build(raw)
+	if err != nil {
+		return false, err
+	}
+
+	w.last = raw
+	previous := Current()
+	if changed := restartRequired(previous, next); len(changed) > 0 {
+		logrus.Warnf("config changes to %s take effect after a restart", strings.Join(changed, ", "))
+	}
+
+	swap(next)
+	logrus.Info("config reloaded from consul")
+	return true, nil
+}
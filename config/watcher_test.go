@@ -0,0 +1,115 @@
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+const validConfig = `{
+	"port": 8002,
+	"appName": "field-service",
+	"signatureKey": "secret",
+	"database": {"host": "localhost", "name": "field", "username": "field"},
+	"gcsBucketName": "fields",
+	"rateLimiterRequest": 10,
+	"rateLimiterTimeSecond": 60,
+	"internalService": {"user": {"host": "http://user-service"}}
+}`
+
+// consulStandIn serves the KV value set through set, like Consul does for
+// GET /v1/kv/<key>?raw.
+type consulStandIn struct {
+	mutex sync.Mutex
+	value string
+	token string
+}
+
+func (c *consulStandIn) set(value string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.value = value
+}
+
+func (c *consulStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if r.URL.Path != "/v1/kv/field-service" || r.Header.Get("X-Consul-Token") != c.token {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write([]byte(c.value))
+}
+
+func TestWatcherPoll(t *testing.T) {
+	consul := &consulStandIn{value: validConfig, token: "consul-token"}
+	server := httptest.NewServer(consul)
+	defer server.Close()
+
+	t.Cleanup(func() {
+		current.Store(nil)
+		subscribers = nil
+	})
+
+	var notified []*AppConfig
+	Subscribe(func(_, next *AppConfig) {
+		notified = append(notified, next)
+	})
+
+	watcher := NewWatcher(server.URL, "field-service", "consul-token", time.Second)
+
+	applied, err := watcher.Poll(context.Background())
+	if err != nil || !applied {
+		t.Fatalf("expected first poll to apply, got %v, %v", applied, err)
+	}
+	if Current().RateLimiterRequest != 10 {
+		t.Errorf("expected rate limit 10, got %d", Current().RateLimiterRequest)
+	}
+
+	applied, err = watcher.Poll(context.Background())
+	if err != nil || applied {
+		t.Errorf("expected unchanged value to be skipped, got %v, %v", applied, err)
+	}
+
+	consul.set(`{"port": 0}`)
+	applied, err = watcher.Poll(context.Background())
+	if err == nil || applied {
+		t.Errorf("expected invalid config to be rejected, got %v, %v", applied, err)
+	}
+	if Current().RateLimiterRequest != 10 {
+		t.Errorf("expected invalid config to be ignored, got rate limit %d", Current().RateLimiterRequest)
+	}
+
+	consul.set(`{not json`)
+	_, err = watcher.Poll(context.Background())
+	if err == nil {
+		t.Error("expected malformed config to be rejected")
+	}
+
+	consul.set(`{
+		"port": 8002,
+		"appName": "field-service",
+		"signatureKey": "rotated",
+		"database": {"host": "localhost", "name": "field", "username": "field"},
+		"gcsBucketName": "fields",
+		"rateLimiterRequest": 25,
+		"rateLimiterTimeSecond": 60,
+		"internalService": {"user": {"host": "http://user-service-v2"}}
+	}`)
+	applied, err = watcher.Poll(context.Background())
+	if err != nil || !applied {
+		t.Fatalf("expected update to apply, got %v, %v", applied, err)
+	}
+	if Current().SignatureKey != "rotated" || Current().InternalService.User.Host != "http://user-service-v2" {
+		t.Errorf("expected updated values, got %+v", Current())
+	}
+
+	if len(notified) != 2 || notified[1].RateLimiterRequest != 25 {
+		t.Errorf("expected subscribers to see two reloads, got %d", len(notified))
+	}
+}
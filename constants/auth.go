@@ -1,5 +1,8 @@
 package constants
 
 const (
-	Token = "token"
+	Token     = "token"
+	UserLogin = "userLogin"
+	UserUUID  = "userUUID"
+	RequestID = "requestID"
 )
@@ -1,16 +1,23 @@
 package error
 
 import (
+	"errors"
 	errField "field-service/constants/error/field"
 	errFieldSchedule "field-service/constants/error/field_schedule"
+	errPricingRule "field-service/constants/error/pricing_rule"
+	errPromo "field-service/constants/error/promo"
+	errTime "field-service/constants/error/time"
 )
 
 func ErrMapping(err error) bool {
 	allErrors := make([]error, 0)
 	allErrors = append(append(GeneralErrors[:], errField.FieldErrors[:]...), errFieldSchedule.FieldScheduleErrors[:]...)
+	allErrors = append(allErrors, errTime.TimeErrors[:]...)
+	allErrors = append(allErrors, errPricingRule.PricingRuleErrors[:]...)
+	allErrors = append(allErrors, errPromo.PromoErrors[:]...)
 
 	for _, item := range allErrors {
-		if err.Error() == item.Error() {
+		if errors.Is(err, item) || err.Error() == item.Error() {
 			return true
 		}
 	}
@@ -5,9 +5,11 @@ import (
 )
 
 var (
-	ErrFieldNotFound = errors.New("field not found")
+	ErrFieldNotFound             = errors.New("field not found")
+	ErrFieldHasUpcomingSchedules = errors.New("field has booked or held schedules from today on")
 )
 
 var FieldErrors = []error{
 	ErrFieldNotFound,
+	ErrFieldHasUpcomingSchedules,
 }
@@ -7,9 +7,15 @@ import (
 var (
 	ErrFieldScheduleNotFound = errors.New("field schedule not found")
 	ErrFieldScheduleIsExist  = errors.New("field schedule is exist")
+	ErrInvalidDateFormat     = errors.New("date must use YYYY-MM-DD format")
+	ErrFieldScheduleIsBooked = errors.New("field schedule is already booked")
+	ErrFieldScheduleIsHeld   = errors.New("field schedule is held by another order")
 )
 
 var FieldScheduleErrors = []error{
 	ErrFieldScheduleNotFound,
 	ErrFieldScheduleIsExist,
+	ErrInvalidDateFormat,
+	ErrFieldScheduleIsBooked,
+	ErrFieldScheduleIsHeld,
 }
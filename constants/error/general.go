@@ -3,12 +3,20 @@ package error
 import "errors"
 
 var (
-	ErrInternalServerError = errors.New("internal server error")
-	ErrSQLError            = errors.New("database server failed to process the query")
-	ErrToManyRequest       = errors.New("too many request")
-	ErrUnauthorized        = errors.New("unauthorized")
-	ErrInvalidToken        = errors.New("invalid token")
-	ErrForbidden           = errors.New("forbidden")
+	ErrInternalServerError   = errors.New("internal server error")
+	ErrSQLError              = errors.New("database server failed to process the query")
+	ErrToManyRequest         = errors.New("too many request")
+	ErrUnauthorized          = errors.New("unauthorized")
+	ErrInvalidToken          = errors.New("invalid token")
+	ErrForbidden             = errors.New("forbidden")
+	ErrInvalidUploadFile     = errors.New("invalid upload file")
+	ErrSizeTooBig            = errors.New("size too big")
+	ErrInvalidSortColumn     = errors.New("invalid sort column")
+	ErrInvalidImageDimension = errors.New("invalid image dimension")
+	ErrSignatureExpired      = errors.New("signature expired")
+	ErrSignatureReplayed     = errors.New("signature already used")
+	ErrUnknownService        = errors.New("unknown service")
+	ErrRouteNotAllowed       = errors.New("route is not allowed for this service")
 )
 
 var GeneralErrors = []error{
@@ -18,4 +26,12 @@ var GeneralErrors = []error{
 	ErrUnauthorized,
 	ErrInvalidToken,
 	ErrForbidden,
+	ErrInvalidUploadFile,
+	ErrSizeTooBig,
+	ErrInvalidSortColumn,
+	ErrInvalidImageDimension,
+	ErrSignatureExpired,
+	ErrSignatureReplayed,
+	ErrUnknownService,
+	ErrRouteNotAllowed,
 }
@@ -0,0 +1,17 @@
+package error
+
+import (
+	"errors"
+)
+
+var (
+	ErrPricingRuleNotFound = errors.New("pricing rule not found")
+	ErrInvalidDaysOfWeek   = errors.New("days of week must be between 0 (sunday) and 6 (saturday)")
+	ErrInvalidDateRange    = errors.New("end date must not be before start date")
+)
+
+var PricingRuleErrors = []error{
+	ErrPricingRuleNotFound,
+	ErrInvalidDaysOfWeek,
+	ErrInvalidDateRange,
+}
@@ -0,0 +1,31 @@
+package error
+
+import (
+	"errors"
+)
+
+var (
+	ErrPromoNotFound        = errors.New("promo not found")
+	ErrPromoCodeIsExist     = errors.New("promo code is exist")
+	ErrPromoNotActive       = errors.New("promo is not active")
+	ErrPromoNotApplicable   = errors.New("promo is not applicable to the selected field")
+	ErrPromoUsageExceeded   = errors.New("promo usage limit has been reached")
+	ErrPromoUserRequired    = errors.New("user id is required for this promo")
+	ErrInvalidUserID        = errors.New("invalid user id")
+	ErrInvalidDiscountType  = errors.New("discount type must be percentage or fixed")
+	ErrInvalidDiscountValue = errors.New("invalid discount value")
+	ErrInvalidPromoPeriod   = errors.New("valid until must be after valid from")
+)
+
+var PromoErrors = []error{
+	ErrPromoNotFound,
+	ErrPromoCodeIsExist,
+	ErrPromoNotActive,
+	ErrPromoNotApplicable,
+	ErrPromoUsageExceeded,
+	ErrPromoUserRequired,
+	ErrInvalidUserID,
+	ErrInvalidDiscountType,
+	ErrInvalidDiscountValue,
+	ErrInvalidPromoPeriod,
+}
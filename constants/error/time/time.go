@@ -0,0 +1,19 @@
+package error
+
+import (
+	"errors"
+)
+
+var (
+	ErrTimeNotFound      = errors.New("time not found")
+	ErrTimeIsExist       = errors.New("time overlaps with an existing time")
+	ErrInvalidTimeFormat = errors.New("time must use HH:MM:SS format")
+	ErrInvalidTimeRange  = errors.New("end time must be after start time")
+)
+
+var TimeErrors = []error{
+	ErrTimeNotFound,
+	ErrTimeIsExist,
+	ErrInvalidTimeFormat,
+	ErrInvalidTimeRange,
+}
@@ -6,19 +6,23 @@ type FieldScheduleStatus int
 const (
 	Available FieldScheduleStatus = 100
 	Booked    FieldScheduleStatus = 200
+	Held      FieldScheduleStatus = 300
 
 	AvailableString FieldScheduleStatusName = "available"
 	BookedString    FieldScheduleStatusName = "booked"
+	HeldString      FieldScheduleStatusName = "held"
 )
 
 var mapFieldScheduleStatusIntToString = map[FieldScheduleStatus]FieldScheduleStatusName{
 	Available: AvailableString,
 	Booked:    BookedString,
+	Held:      HeldString,
 }
 
 var mapFieldScheduleStatusStringToInt = map[FieldScheduleStatusName]FieldScheduleStatus{
 	AvailableString: Available,
 	BookedString:    Booked,
+	HeldString:      Held,
 }
 
 func (f FieldScheduleStatus) GetStatusString() FieldScheduleStatusName {
@@ -28,3 +32,14 @@ func (f FieldScheduleStatus) GetStatusString() FieldScheduleStatusName {
 func (f FieldScheduleStatusName) GetStatusInt() FieldScheduleStatus {
 	return mapFieldScheduleStatusStringToInt[f]
 }
+
+type FieldScheduleEventType string
+
+const (
+	FieldScheduleEventVersion = 1
+
+	FieldScheduleCreated  FieldScheduleEventType = "field_schedule.created"
+	FieldScheduleBooked   FieldScheduleEventType = "field_schedule.booked"
+	FieldScheduleReleased FieldScheduleEventType = "field_schedule.released"
+	FieldScheduleDeleted  FieldScheduleEventType = "field_schedule.deleted"
+)
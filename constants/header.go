@@ -6,5 +6,7 @@ var (
 	XServiceName  = textproto.CanonicalMIMEHeaderKey("x-service-name")
 	XApiKey       = textproto.CanonicalMIMEHeaderKey("x-api-key")
 	XRequestAt    = textproto.CanonicalMIMEHeaderKey("x-request-at")
+	XRequestID    = textproto.CanonicalMIMEHeaderKey("x-request-id")
+	XRequestNonce = textproto.CanonicalMIMEHeaderKey("x-request-nonce")
 	Authorization = textproto.CanonicalMIMEHeaderKey("authorization")
 )
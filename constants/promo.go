@@ -0,0 +1,8 @@
+package constants
+
+type PromoDiscountType string
+
+const (
+	Percentage PromoDiscountType = "percentage"
+	Fixed      PromoDiscountType = "fixed"
+)
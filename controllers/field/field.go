@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	errValidation "field-service/common/error"
+	"field-service/common/response"
+	"field-service/domain/dto"
+	"field-service/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+type FieldController struct {
+	service services.IServiceRegistry
+}
+
+type IFieldController interface {
+	GetAllWithPagination(*gin.Context)
+	GetByUUID(*gin.Context)
+	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
+}
+
+func NewFieldController(service services.IServiceRegistry) IFieldController {
+	return &FieldController{service: service}
+}
+
+func (f *FieldController) GetAllWithPagination(ctx *gin.Context) {
+	var params dto.FieldRequestParam
+	err := ctx.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := f.service.GetField().GetAllWithPagination(ctx.Request.Context(), &params)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (f *FieldController) GetByUUID(ctx *gin.Context) {
+	result, err := f.service.GetField().GetByUUID(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (f *FieldController) Create(ctx *gin.Context) {
+	var request dto.FieldRequest
+	err := ctx.ShouldBindWith(&request, binding.FormMultipart)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := f.service.GetField().Create(ctx.Request.Context(), &request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (f *FieldController) Update(ctx *gin.Context) {
+	var request dto.UpdateFieldRequest
+	err := ctx.ShouldBindWith(&request, binding.FormMultipart)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := f.service.GetField().Update(ctx.Request.Context(), ctx.Param("uuid"), &request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (f *FieldController) Delete(ctx *gin.Context) {
+	err := f.service.GetField().Delete(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
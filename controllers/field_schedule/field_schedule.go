@@ -0,0 +1,270 @@
+package controllers
+
+import (
+	errValidation "field-service/common/error"
+	"field-service/common/response"
+	"field-service/domain/dto"
+	"field-service/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type FieldScheduleController struct {
+	service services.IServiceRegistry
+}
+
+type IFieldScheduleController interface {
+	GetAllByFieldIDAndDate(*gin.Context)
+	GetByUUID(*gin.Context)
+	GenerateScheduleForOneMonth(*gin.Context)
+	UpdateStatus(*gin.Context)
+	Hold(*gin.Context)
+	Release(*gin.Context)
+}
+
+func NewFieldScheduleController(service services.IServiceRegistry) IFieldScheduleController {
+	return &FieldScheduleController{service: service}
+}
+
+func (f *FieldScheduleController) GetAllByFieldIDAndDate(ctx *gin.Context) {
+	var params dto.FieldScheduleByFieldIDAndDateRequestParam
+	err := ctx.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().GetAllByFieldIDAndDate(
+		ctx.Request.Context(),
+		ctx.Param("uuid"),
+		params.Date,
+	)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (f *FieldScheduleController) GetByUUID(ctx *gin.Context) {
+	result, err := f.service.GetFieldSchedule().GetByUUID(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (f *FieldScheduleController) GenerateScheduleForOneMonth(ctx *gin.Context) {
+	var request dto.GenerateFieldScheduleForOneMonthRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().GenerateScheduleForOneMonth(ctx.Request.Context(), &request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (f *FieldScheduleController) UpdateStatus(ctx *gin.Context) {
+	var request dto.UpdateStatusFieldScheduleRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	err = f.service.GetFieldSchedule().UpdateStatus(ctx.Request.Context(), &request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (f *FieldScheduleController) Hold(ctx *gin.Context) {
+	var request dto.HoldFieldScheduleRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().Hold(ctx.Request.Context(), &request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (f *FieldScheduleController) Release(ctx *gin.Context) {
+	var request dto.ReleaseFieldScheduleRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().Release(ctx.Request.Context(), &request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
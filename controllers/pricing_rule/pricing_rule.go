@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	errValidation "field-service/common/error"
+	"field-service/common/response"
+	"field-service/domain/dto"
+	"field-service/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type PricingRuleController struct {
+	service services.IServiceRegistry
+}
+
+type IPricingRuleController interface {
+	GetAll(*gin.Context)
+	GetByUUID(*gin.Context)
+	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
+}
+
+func NewPricingRuleController(service services.IServiceRegistry) IPricingRuleController {
+	return &PricingRuleController{service: service}
+}
+
+func (p *PricingRuleController) GetAll(ctx *gin.Context) {
+	var params dto.PricingRuleRequestParam
+	err := ctx.ShouldBindQuery(&params)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	result, err := p.service.GetPricingRule().GetAll(ctx.Request.Context(), &params)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (p *PricingRuleController) GetByUUID(ctx *gin.Context) {
+	result, err := p.service.GetPricingRule().GetByUUID(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (p *PricingRuleController) Create(ctx *gin.Context) {
+	var request dto.PricingRuleRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := p.service.GetPricingRule().Create(ctx.Request.Context(), &request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (p *PricingRuleController) Update(ctx *gin.Context) {
+	var request dto.PricingRuleRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := p.service.GetPricingRule().Update(ctx.Request.Context(), ctx.Param("uuid"), &request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (p *PricingRuleController) Delete(ctx *gin.Context) {
+	err := p.service.GetPricingRule().Delete(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
@@ -0,0 +1,214 @@
+package controllers
+
+import (
+	errValidation "field-service/common/error"
+	"field-service/common/response"
+	"field-service/domain/dto"
+	"field-service/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type PromoController struct {
+	service services.IServiceRegistry
+}
+
+type IPromoController interface {
+	GetAll(*gin.Context)
+	GetByUUID(*gin.Context)
+	Create(*gin.Context)
+	Update(*gin.Context)
+	Delete(*gin.Context)
+	Quote(*gin.Context)
+}
+
+func NewPromoController(service services.IServiceRegistry) IPromoController {
+	return &PromoController{service: service}
+}
+
+func (p *PromoController) GetAll(ctx *gin.Context) {
+	result, err := p.service.GetPromo().GetAll(ctx.Request.Context())
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (p *PromoController) GetByUUID(ctx *gin.Context) {
+	result, err := p.service.GetPromo().GetByUUID(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (p *PromoController) Create(ctx *gin.Context) {
+	var request dto.PromoRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := p.service.GetPromo().Create(ctx.Request.Context(), &request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (p *PromoController) Update(ctx *gin.Context) {
+	var request dto.PromoRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := p.service.GetPromo().Update(ctx.Request.Context(), ctx.Param("uuid"), &request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (p *PromoController) Delete(ctx *gin.Context) {
+	err := p.service.GetPromo().Delete(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (p *PromoController) Quote(ctx *gin.Context) {
+	var request dto.PromoQuoteRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := p.service.GetPromo().Quote(ctx.Request.Context(), &request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
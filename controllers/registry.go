@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"field-service/clients"
+	fieldController "field-service/controllers/field"
+	fieldScheduleController "field-service/controllers/field_schedule"
+	pricingRuleController "field-service/controllers/pricing_rule"
+	promoController "field-service/controllers/promo"
+	timeController "field-service/controllers/time"
+	userCacheController "field-service/controllers/user_cache"
+	"field-service/services"
+)
+
+type Registry struct {
+	service services.IServiceRegistry
+	client  clients.IClientRegistry
+}
+
+type IControllerRegistry interface {
+	GetField() fieldController.IFieldController
+	GetFieldSchedule() fieldScheduleController.IFieldScheduleController
+	GetPricingRule() pricingRuleController.IPricingRuleController
+	GetPromo() promoController.IPromoController
+	GetTime() timeController.ITimeController
+	GetUserCache() userCacheController.IUserCacheController
+}
+
+func NewControllerRegistry(service services.IServiceRegistry, client clients.IClientRegistry) IControllerRegistry {
+	return &Registry{service: service, client: client}
+}
+
+func (r *Registry) GetField() fieldController.IFieldController {
+	return fieldController.NewFieldController(r.service)
+}
+
+func (r *Registry) GetTime() timeController.ITimeController {
+	return timeController.NewTimeController(r.service)
+}
+
+func (r *Registry) GetFieldSchedule() fieldScheduleController.IFieldScheduleController {
+	return fieldScheduleController.NewFieldScheduleController(r.service)
+}
+
+func (r *Registry) GetPricingRule() pricingRuleController.IPricingRuleController {
+	return pricingRuleController.NewPricingRuleController(r.service)
+}
+
+func (r *Registry) GetPromo() promoController.IPromoController {
+	return promoController.NewPromoController(r.service)
+}
+
+func (r *Registry) GetUserCache() userCacheController.IUserCacheController {
+	return userCacheController.NewUserCacheController(r.client)
+}
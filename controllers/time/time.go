@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	errValidation "field-service/common/error"
+	"field-service/common/response"
+	"field-service/domain/dto"
+	"field-service/services"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type TimeController struct {
+	service services.IServiceRegistry
+}
+
+type ITimeController interface {
+	GetAll(*gin.Context)
+	GetByUUID(*gin.Context)
+	Create(*gin.Context)
+	Update(*gin.Context)
+}
+
+func NewTimeController(service services.IServiceRegistry) ITimeController {
+	return &TimeController{service: service}
+}
+
+func (t *TimeController) GetAll(ctx *gin.Context) {
+	result, err := t.service.GetTime().GetAll(ctx.Request.Context())
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (t *TimeController) GetByUUID(ctx *gin.Context) {
+	result, err := t.service.GetTime().GetByUUID(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (t *TimeController) Create(ctx *gin.Context) {
+	var request dto.TimeRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := t.service.GetTime().Create(ctx.Request.Context(), &request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusCreated,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (t *TimeController) Update(ctx *gin.Context) {
+	var request dto.TimeRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := t.service.GetTime().Update(ctx.Request.Context(), ctx.Param("uuid"), &request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"field-service/clients"
+	errValidation "field-service/common/error"
+	"field-service/common/response"
+	"field-service/domain/dto"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type UserCacheController struct {
+	client clients.IClientRegistry
+}
+
+type IUserCacheController interface {
+	Evict(*gin.Context)
+}
+
+func NewUserCacheController(client clients.IClientRegistry) IUserCacheController {
+	return &UserCacheController{client: client}
+}
+
+func (u *UserCacheController) Evict(ctx *gin.Context) {
+	var request dto.EvictUserCacheRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpRresponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errValidation.ErrValidationResponse(err)
+		response.HttpRresponse(response.ParamHttpResp{
+			Code:    http.StatusUnprocessableEntity,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	u.client.UserCache().Evict(strings.TrimPrefix(request.Token, "Bearer "))
+	response.HttpRresponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
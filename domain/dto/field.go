@@ -27,6 +27,7 @@ type FieldResponse struct {
 	Name         string     `json:"name"`
 	PricePerHour any        `json:"pricePerHour"`
 	Images       []string   `json:"images"`
+	Thumbnails   []string   `json:"thumbnails"`
 	CreatedAt    *time.Time `json:"createdAt"`
 	UpdatedAt    *time.Time `json:"updatedAt"`
 }
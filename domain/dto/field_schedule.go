@@ -23,9 +23,21 @@ type UpdateFieldScheduleRequest struct {
 }
 
 type UpdateStatusFieldScheduleRequest struct {
+	OrderID          *string  `json:"orderID"`
+	UserID           *string  `json:"userID"`
+	PromoCode        *string  `json:"promoCode"`
 	FieldScheduleIDs []string `json:"fieldScheduleIDs" validate:"required"`
 }
 
+type HoldFieldScheduleRequest struct {
+	OrderID          string   `json:"orderID" validate:"required"`
+	FieldScheduleIDs []string `json:"fieldScheduleIDs" validate:"required"`
+}
+
+type ReleaseFieldScheduleRequest struct {
+	OrderID string `json:"orderID" validate:"required"`
+}
+
 type FieldScheduleResponse struct {
 	UUID         uuid.UUID                         `json:"uuid"`
 	FieldName    string                            `json:"fieldName"`
@@ -38,10 +50,11 @@ type FieldScheduleResponse struct {
 }
 
 type FieldScheduleForBookingResponse struct {
-	UUID         uuid.UUID `json:"uuid"`
-	PricePerHour string    `json:"pricePerHour"`
-	Date         string    `json:"date"`
-	Time         string    `json:"time"`
+	UUID         uuid.UUID                         `json:"uuid"`
+	PricePerHour string                            `json:"pricePerHour"`
+	Date         string                            `json:"date"`
+	Status       constants.FieldScheduleStatusName `json:"status"`
+	Time         string                            `json:"time"`
 }
 
 type FieldScheduleRequestParam struct {
@@ -54,3 +67,30 @@ type FieldScheduleRequestParam struct {
 type FieldScheduleByFieldIDAndDateRequestParam struct {
 	Date string `form:"date" validate:"required"`
 }
+
+type GenerateFieldScheduleForOneMonthResponse struct {
+	FieldName    string   `json:"fieldName"`
+	StartDate    string   `json:"startDate"`
+	EndDate      string   `json:"endDate"`
+	TotalCreated int      `json:"totalCreated"`
+	CreatedDates []string `json:"createdDates"`
+	SkippedDates []string `json:"skippedDates"`
+}
+
+type HoldFieldScheduleResponse struct {
+	OrderID          string    `json:"orderID"`
+	FieldScheduleIDs []string  `json:"fieldScheduleIDs"`
+	HeldUntil        time.Time `json:"heldUntil"`
+}
+
+type ReleaseFieldScheduleResponse struct {
+	OrderID       string `json:"orderID"`
+	TotalReleased int64  `json:"totalReleased"`
+}
+
+type FieldScheduleStatusCount struct {
+	FieldUUID uuid.UUID
+	FieldCode string
+	Status    constants.FieldScheduleStatus
+	Total     int64
+}
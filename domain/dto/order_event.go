@@ -0,0 +1,9 @@
+package dto
+
+type OrderEvent struct {
+	EventID          string   `json:"eventID" validate:"required"`
+	OrderID          string   `json:"orderID" validate:"required"`
+	UserID           *string  `json:"userID"`
+	PromoCode        *string  `json:"promoCode"`
+	FieldScheduleIDs []string `json:"fieldScheduleIDs" validate:"required"`
+}
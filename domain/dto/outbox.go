@@ -0,0 +1,16 @@
+package dto
+
+import (
+	"field-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FieldScheduleEvent struct {
+	Version    int                              `json:"version"`
+	EventID    uuid.UUID                        `json:"eventID"`
+	Type       constants.FieldScheduleEventType `json:"type"`
+	OccurredAt time.Time                        `json:"occurredAt"`
+	Data       FieldScheduleResponse            `json:"data"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PricingRuleRequest struct {
+	FieldID      string  `json:"fieldID" validate:"required"`
+	Name         string  `json:"name" validate:"required"`
+	DaysOfWeek   []int   `json:"daysOfWeek" validate:"required,min=1"`
+	StartTime    string  `json:"startTime" validate:"required"`
+	EndTime      string  `json:"endTime" validate:"required"`
+	StartDate    *string `json:"startDate"`
+	EndDate      *string `json:"endDate"`
+	PricePerHour int     `json:"pricePerHour" validate:"required"`
+	Priority     int     `json:"priority"`
+}
+
+type PricingRuleResponse struct {
+	UUID         uuid.UUID  `json:"uuid"`
+	FieldID      uuid.UUID  `json:"fieldID"`
+	FieldName    string     `json:"fieldName"`
+	Name         string     `json:"name"`
+	DaysOfWeek   []int      `json:"daysOfWeek"`
+	StartTime    string     `json:"startTime"`
+	EndTime      string     `json:"endTime"`
+	StartDate    *string    `json:"startDate"`
+	EndDate      *string    `json:"endDate"`
+	PricePerHour int        `json:"pricePerHour"`
+	Priority     int        `json:"priority"`
+	CreatedAt    *time.Time `json:"createdAt"`
+	UpdatedAt    *time.Time `json:"updatedAt"`
+}
+
+type PricingRuleRequestParam struct {
+	FieldID *string `form:"fieldID"`
+}
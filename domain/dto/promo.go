@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"field-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PromoRequest struct {
+	Code            string    `json:"code" validate:"required"`
+	Name            string    `json:"name" validate:"required"`
+	DiscountType    string    `json:"discountType" validate:"required"`
+	DiscountValue   int       `json:"discountValue" validate:"required"`
+	ValidFrom       time.Time `json:"validFrom" validate:"required"`
+	ValidUntil      time.Time `json:"validUntil" validate:"required"`
+	MaxUsage        *int      `json:"maxUsage"`
+	MaxUsagePerUser *int      `json:"maxUsagePerUser"`
+	AllowedFieldIDs []string  `json:"allowedFieldIDs"`
+}
+
+type PromoResponse struct {
+	UUID            uuid.UUID                   `json:"uuid"`
+	Code            string                      `json:"code"`
+	Name            string                      `json:"name"`
+	DiscountType    constants.PromoDiscountType `json:"discountType"`
+	DiscountValue   int                         `json:"discountValue"`
+	ValidFrom       time.Time                   `json:"validFrom"`
+	ValidUntil      time.Time                   `json:"validUntil"`
+	MaxUsage        *int                        `json:"maxUsage"`
+	MaxUsagePerUser *int                        `json:"maxUsagePerUser"`
+	AllowedFieldIDs []string                    `json:"allowedFieldIDs"`
+	CreatedAt       *time.Time                  `json:"createdAt"`
+	UpdatedAt       *time.Time                  `json:"updatedAt"`
+}
+
+type PromoQuoteRequest struct {
+	FieldScheduleIDs []string `json:"fieldScheduleIDs" validate:"required"`
+	PromoCode        *string  `json:"promoCode"`
+	UserID           *string  `json:"userID"`
+}
+
+type PromoQuoteItemResponse struct {
+	UUID         uuid.UUID `json:"uuid"`
+	FieldName    string    `json:"fieldName"`
+	Date         string    `json:"date"`
+	Time         string    `json:"time"`
+	PricePerHour string    `json:"pricePerHour"`
+}
+
+type PromoQuoteResponse struct {
+	Items       []PromoQuoteItemResponse `json:"items"`
+	PromoCode   *string                  `json:"promoCode"`
+	Subtotal    string                   `json:"subtotal"`
+	Discount    string                   `json:"discount"`
+	Total       string                   `json:"total"`
+	TotalAmount int                      `json:"totalAmount"`
+}
+
+type PromoRedeemParam struct {
+	PromoCode        string
+	UserID           *string
+	OrderID          *string
+	FieldScheduleIDs []string
+}
@@ -0,0 +1,5 @@
+package dto
+
+type EvictUserCacheRequest struct {
+	Token string `json:"token" validate:"required"`
+}
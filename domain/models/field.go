@@ -14,6 +14,7 @@ type Field struct {
 	Name         string         `gorm:"type:varchar(100);not null"`
 	PricePerHour int            `gorm:"type:int;not null"`
 	Images       pq.StringArray `gorm:"type:text[]; not null"`
+	Thumbnails   pq.StringArray `gorm:"type:text[]"`
 	CreatedAt    *time.Time
 	UpdatedAt    *time.Time
 	DeletedAt    *time.Time
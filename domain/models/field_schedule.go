@@ -10,10 +10,12 @@ import (
 type FieldSchedule struct {
 	ID        uint                          `gorm:"primaryKey;autoIncrement"`
 	UUID      uuid.UUID                     `gorm:"type:uuid;not null"`
-	FieldID   uint                          `gorm:"type:int;not null"`
-	TimeID    uint                          `gorm:"type:int; not null"`
-	Date      time.Time                     `gorm:"type:date; not null"`
+	FieldID   uint                          `gorm:"type:int;not null;uniqueIndex:idx_field_schedules_slot,priority:1,where:deleted_at IS NULL"`
+	TimeID    uint                          `gorm:"type:int; not null;uniqueIndex:idx_field_schedules_slot,priority:3"`
+	Date      time.Time                     `gorm:"type:date; not null;uniqueIndex:idx_field_schedules_slot,priority:2"`
 	Status    constants.FieldScheduleStatus `gorm:"type:int; not null"`
+	OrderID   *string                       `gorm:"type:varchar(100)"`
+	HeldUntil *time.Time
 	CreatedAt *time.Time
 	UpdatedAt *time.Time
 	DeletedAt *time.Time
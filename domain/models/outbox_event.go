@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxEvent is a message waiting to be published. It is written in the same
+// transaction as the change it describes and relayed afterwards.
+type OutboxEvent struct {
+	ID            uint       `gorm:"primaryKey;autoIncrement"`
+	UUID          uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex"`
+	Subject       string     `gorm:"type:varchar(100);not null"`
+	Payload       string     `gorm:"type:jsonb;not null"`
+	Attempts      int        `gorm:"type:int;not null;default:0"`
+	LastError     *string    `gorm:"type:text"`
+	NextAttemptAt time.Time  `gorm:"not null;index"`
+	SentAt        *time.Time `gorm:"index"`
+	CreatedAt     *time.Time
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type PricingRule struct {
+	ID           uint          `gorm:"primaryKey;autoIncrement"`
+	UUID         uuid.UUID     `gorm:"type:uuid;not null"`
+	FieldID      uint          `gorm:"type:int;not null"`
+	Name         string        `gorm:"type:varchar(100);not null"`
+	DaysOfWeek   pq.Int64Array `gorm:"type:int[];not null"`
+	StartTime    string        `gorm:"type:time without time zone;not null"`
+	EndTime      string        `gorm:"type:time without time zone;not null"`
+	StartDate    *time.Time    `gorm:"type:date"`
+	EndDate      *time.Time    `gorm:"type:date"`
+	PricePerHour int           `gorm:"type:int;not null"`
+	Priority     int           `gorm:"type:int;not null;default:0"`
+	CreatedAt    *time.Time
+	UpdatedAt    *time.Time
+
+	// Relation to field table
+	Field Field `gorm:"foreignKey:FieldID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
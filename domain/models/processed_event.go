@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// ProcessedEvent records an inbound event that has already been handled so a
+// redelivered message is not applied twice.
+type ProcessedEvent struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	EventID     string    `gorm:"type:varchar(100);not null;uniqueIndex"`
+	Subject     string    `gorm:"type:varchar(100);not null"`
+	ProcessedAt time.Time `gorm:"not null"`
+}
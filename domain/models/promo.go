@@ -0,0 +1,40 @@
+package models
+
+import (
+	"field-service/constants"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type Promo struct {
+	ID              uint                        `gorm:"primaryKey;autoIncrement"`
+	UUID            uuid.UUID                   `gorm:"type:uuid;not null"`
+	Code            string                      `gorm:"type:varchar(50);not null;uniqueIndex"`
+	Name            string                      `gorm:"type:varchar(100);not null"`
+	DiscountType    constants.PromoDiscountType `gorm:"type:varchar(20);not null"`
+	DiscountValue   int                         `gorm:"type:int;not null"`
+	ValidFrom       time.Time                   `gorm:"not null"`
+	ValidUntil      time.Time                   `gorm:"not null"`
+	MaxUsage        *int                        `gorm:"type:int"`
+	MaxUsagePerUser *int                        `gorm:"type:int"`
+	AllowedFieldIDs pq.StringArray              `gorm:"type:text[]"`
+	CreatedAt       *time.Time
+	UpdatedAt       *time.Time
+}
+
+type PromoRedemption struct {
+	ID               uint           `gorm:"primaryKey;autoIncrement"`
+	UUID             uuid.UUID      `gorm:"type:uuid;not null"`
+	PromoID          uint           `gorm:"type:int;not null"`
+	UserID           *uuid.UUID     `gorm:"type:uuid"`
+	OrderID          *string        `gorm:"type:varchar(100)"`
+	FieldScheduleIDs pq.StringArray `gorm:"type:text[];not null"`
+	Subtotal         int            `gorm:"type:int;not null"`
+	Discount         int            `gorm:"type:int;not null"`
+	CreatedAt        *time.Time
+
+	// Relation to promo table
+	Promo Promo `gorm:"foreignKey:PromoID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
@@ -0,0 +1,87 @@
+package middlewares
+
+import (
+	"field-service/common/logger"
+	"field-service/constants"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const maxRequestIDLength = 128
+
+// validRequestID accepts ids made of letters, digits and -_.: so a caller
+// supplied header cannot inject anything into the logs.
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	for _, r := range requestID {
+		isAlphaNumeric := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+		if !isAlphaNumeric && r != '-' && r != '_' && r != '.' && r != ':' {
+			return false
+		}
+	}
+	return true
+}
+
+// RequestID takes the X-Request-ID header or generates one, stores it in the
+// request context for the logger and echoes it on the response.
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := ctx.GetHeader(constants.XRequestID)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		ctx.Set(constants.RequestID, requestID)
+		ctx.Request = ctx.Request.WithContext(logger.WithRequestID(ctx.Request.Context(), requestID))
+		ctx.Writer.Header().Set(constants.XRequestID, requestID)
+		ctx.Next()
+	}
+}
+
+// AccessLog writes one structured line per request once it has been served.
+func AccessLog() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = ctx.Request.URL.Path
+		}
+
+		fields := logrus.Fields{
+			"method":     ctx.Request.Method,
+			"route":      route,
+			"path":       ctx.Request.URL.Path,
+			"status":     ctx.Writer.Status(),
+			"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+			"client_ip":  ctx.ClientIP(),
+			"bytes":      max(ctx.Writer.Size(), 0),
+		}
+		if serviceName := ctx.GetHeader(constants.XServiceName); serviceName != "" {
+			fields["service_name"] = serviceName
+		}
+		if user, ok := GetUserLogin(ctx); ok {
+			fields["user_uuid"] = user.UUID.String()
+		}
+		if len(ctx.Errors) > 0 {
+			fields["errors"] = ctx.Errors.String()
+		}
+
+		entry := logger.FromContext(ctx.Request.Context()).WithFields(fields)
+		switch status := ctx.Writer.Status(); {
+		case status >= 500:
+			entry.Error("request completed")
+		case status >= 400:
+			entry.Warn("request completed")
+		default:
+			entry.Info("request completed")
+		}
+	}
+}
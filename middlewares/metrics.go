@@ -0,0 +1,61 @@
+package middlewares
+
+import (
+	"crypto/subtle"
+	"field-service/common/metrics"
+	"field-service/config"
+	"field-service/constants"
+	errConstant "field-service/constants/error"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests that did not match any route, so unknown
+// paths cannot blow up the number of series.
+const unmatchedRoute = "unmatched"
+
+func routeLabel(ctx *gin.Context) string {
+	route := ctx.FullPath()
+	if route == "" {
+		return unmatchedRoute
+	}
+	return route
+}
+
+// Metrics records request counts and latency per route template.
+func Metrics() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := routeLabel(ctx)
+		metrics.HTTPRequestsTotal.
+			WithLabelValues(ctx.Request.Method, route, strconv.Itoa(ctx.Writer.Status())).
+			Inc()
+		metrics.HTTPRequestDuration.
+			WithLabelValues(ctx.Request.Method, route).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+// MetricsAuth requires the configured bearer token on the metrics endpoint
+// unless metrics.authEnabled is turned off.
+func MetricsAuth() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		metricsConfig := config.Current().Metrics
+		if !metricsConfig.AuthEnabled {
+			ctx.Next()
+			return
+		}
+
+		token := extractBearerToken(ctx.GetHeader(constants.Authorization))
+		if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(metricsConfig.Token)) != 1 {
+			responseUnauthorized(ctx, errConstant.ErrUnauthorized.Error())
+			return
+		}
+
+		ctx.Next()
+	}
+}
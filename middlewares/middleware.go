@@ -1,9 +1,16 @@
 package middlewares
 
 import (
+	"context"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/hex"
+	"errors"
 	"field-service/clients"
+	clientUser "field-service/clients/user"
+	"field-service/common/auth"
+	"field-service/common/logger"
+	"field-service/common/metrics"
 	"field-service/common/response"
 	"field-service/config"
 	"field-service/constants"
@@ -11,18 +18,21 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
 	"github.com/gin-gonic/gin"
-	"github.com/sirupsen/logrus"
+	"github.com/patrickmn/go-cache"
 )
 
 func HandlePanic() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
-				logrus.Errorf("Recovered from panic: %v", err)
+				logger.FromContext(ctx.Request.Context()).Errorf("Recovered from panic: %v", err)
 				ctx.JSON(http.StatusInternalServerError, response.Response{
 					Status:  constants.Error,
 					Message: errConstant.ErrInternalServerError.Error(),
@@ -38,6 +48,7 @@ func RateLimit(lmt *limiter.Limiter) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		err := tollbooth.LimitByRequest(lmt, ctx.Writer, ctx.Request)
 		if err != nil {
+			metrics.RateLimitRejectedTotal.WithLabelValues(routeLabel(ctx)).Inc()
 			ctx.JSON(http.StatusTooManyRequests, response.Response{
 				Status:  constants.Error,
 				Message: errConstant.ErrToManyRequest.Error(),
@@ -60,10 +71,43 @@ func contains(roles []string, role string) bool {
 	return slices.Contains(roles, role)
 }
 
+var tokenVerifier auth.IVerifier
+
+// SetTokenVerifier enables local JWT verification in CheckRole. Without a
+// verifier every check is resolved by user-service.
+func SetTokenVerifier(verifier auth.IVerifier) {
+	tokenVerifier = verifier
+}
+
+func resolveUser(ctx *gin.Context, client clients.IClientRegistry) (*clientUser.UserData, error) {
+	if tokenVerifier == nil {
+		return client.UserSvc().GetUserByToken(ctx.Request.Context())
+	}
+
+	tokenString, _ := ctx.Request.Context().Value(constants.Token).(string)
+	claims, err := tokenVerifier.Verify(tokenString)
+	if err != nil {
+		if errors.Is(err, auth.ErrUnknownKey) && config.Config.JWT.RemoteFallback {
+			return client.UserSvc().GetUserByToken(ctx.Request.Context())
+		}
+		return nil, err
+	}
+
+	return &clientUser.UserData{
+		UUID:        claims.UUID,
+		Name:        claims.Name,
+		Username:    claims.Username,
+		Email:       claims.Email,
+		Role:        claims.Role,
+		PhoneNumber: claims.PhoneNumber,
+	}, nil
+}
+
 func CheckRole(roles []string, client clients.IClientRegistry) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		user, err := client.UserSvc().GetUserByToken(ctx.Request.Context())
+		user, err := resolveUser(ctx, client)
 		if err != nil {
+			logger.FromContext(ctx.Request.Context()).Debugf("failed to resolve user: %v", err)
 			responseUnauthorized(ctx, errConstant.ErrUnauthorized.Error())
 			return
 		}
@@ -73,10 +117,33 @@ func CheckRole(roles []string, client clients.IClientRegistry) gin.HandlerFunc {
 			return
 		}
 
+		ctx.Set(constants.UserLogin, user)
+		requestCtx := context.WithValue(ctx.Request.Context(), constants.UserLogin, user)
+		requestCtx = context.WithValue(requestCtx, constants.UserUUID, user.UUID.String())
+		ctx.Request = ctx.Request.WithContext(requestCtx)
 		ctx.Next()
 	}
 }
 
+// GetUserLogin returns the user resolved by CheckRole.
+func GetUserLogin(ctx *gin.Context) (*clientUser.UserData, bool) {
+	value, ok := ctx.Get(constants.UserLogin)
+	if !ok {
+		return nil, false
+	}
+
+	user, ok := value.(*clientUser.UserData)
+	return user, ok
+}
+
+func extractBearerToken(token string) string {
+	arrayToken := strings.Split(token, " ")
+	if len(arrayToken) == 2 {
+		return arrayToken[1]
+	}
+	return ""
+}
+
 func Authenticate() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var err error
@@ -92,10 +159,20 @@ func Authenticate() gin.HandlerFunc {
 			return
 		}
 
+		tokenString := extractBearerToken(token)
+		if tokenString == "" {
+			responseUnauthorized(c, errConstant.ErrInvalidToken.Error())
+			return
+		}
+
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), constants.Token, tokenString))
 		c.Next()
 	}
 }
 
+// AuthenticateWithoutToken guards the internal routes other services call
+// without a user token. On top of the signature, the caller must have the
+// route in its serviceAuth allow-list.
 func AuthenticateWithoutToken() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		err := validateApiKey(c)
@@ -104,23 +181,134 @@ func AuthenticateWithoutToken() gin.HandlerFunc {
 			return
 		}
 
+		if !isRouteAllowed(c, c.GetHeader(constants.XServiceName)) {
+			responseUnauthorized(c, errConstant.ErrRouteNotAllowed.Error())
+			return
+		}
+
 		c.Next()
 	}
 }
 
+const defaultSignatureSkew = 5 * time.Minute
+
+// nonceStore remembers nonces seen within the skew window so a captured
+// x-api-key header cannot be replayed. It lives in memory, so it only stops
+// replays sent to the same replica; a replay sent to another replica within
+// the skew window is still accepted.
+var nonceStore = cache.New(2*defaultSignatureSkew, time.Minute)
+
+func signatureSkew() time.Duration {
+	skewSecond := config.Current().SignatureSkewSecond
+	if skewSecond <= 0 {
+		return defaultSignatureSkew
+	}
+	return time.Duration(skewSecond) * time.Second
+}
+
+func validateRequestAt(requestAt string, skew time.Duration) error {
+	unixTime, err := strconv.ParseInt(requestAt, 10, 64)
+	if err != nil {
+		return errConstant.ErrUnauthorized
+	}
+
+	diff := time.Since(time.Unix(unixTime, 0))
+	if diff > skew || diff < -skew {
+		return errConstant.ErrSignatureExpired
+	}
+	return nil
+}
+
+// signatureKeys returns the active keys of a calling service. Without
+// serviceAuth every caller signs with the legacy signatureKey. Once it is
+// configured, only listed services with at least one key are accepted. Keys
+// are read from the current config so rotations apply on reload.
+func signatureKeys(serviceName string) ([]string, error) {
+	cfg := config.Current()
+	if len(cfg.ServiceAuth) == 0 {
+		return []string{cfg.SignatureKey}, nil
+	}
+
+	keys := slices.DeleteFunc(
+		slices.Clone(cfg.ServiceAuth[serviceName].Keys),
+		func(key string) bool { return key == "" },
+	)
+	if len(keys) == 0 {
+		return nil, errConstant.ErrUnknownService
+	}
+	return keys, nil
+}
+
+// isRouteAllowed checks the serviceAuth allow-list of an internal route. Once
+// serviceAuth is configured, only listed services may call internal routes.
+func isRouteAllowed(ctx *gin.Context, serviceName string) bool {
+	serviceAuth := config.Current().ServiceAuth
+	if len(serviceAuth) == 0 {
+		return true
+	}
+
+	route := fmt.Sprintf("%s %s", ctx.Request.Method, ctx.FullPath())
+	for _, allowed := range serviceAuth[serviceName].Routes {
+		if allowed == "*" || allowed == route {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSignature compares against every key so rotation can keep the old and
+// new key active side by side, without returning early on a match.
+func matchSignature(apiKey, serviceName, requestAt, nonce string, keys []string) bool {
+	matched := 0
+	for _, key := range keys {
+		validateKey := fmt.Sprintf("%s:%s:%s", serviceName, key, requestAt)
+		if nonce != "" {
+			validateKey = fmt.Sprintf("%s:%s", validateKey, nonce)
+		}
+		hash := sha256.New()
+		hash.Write([]byte(validateKey))
+		resultHash := hex.EncodeToString(hash.Sum(nil))
+		matched |= subtle.ConstantTimeCompare([]byte(apiKey), []byte(resultHash))
+	}
+	return matched == 1
+}
+
 func validateApiKey(ctx *gin.Context) error {
 	apiKey := ctx.GetHeader(constants.XApiKey)
 	requestAt := ctx.GetHeader(constants.XRequestAt)
 	serviceName := ctx.GetHeader(constants.XServiceName)
-	signatureKey := config.Config.SignatureKey
+	nonce := ctx.GetHeader(constants.XRequestNonce)
+	if nonce != "" && !validRequestID(nonce) {
+		return errConstant.ErrUnauthorized
+	}
 
-	validateKey := fmt.Sprintf("%s:%s:%s", serviceName, signatureKey, requestAt)
-	hash := sha256.New()
-	hash.Write([]byte(validateKey))
-	resultHash := hex.EncodeToString(hash.Sum(nil))
+	keys, err := signatureKeys(serviceName)
+	if err != nil {
+		return err
+	}
 
-	if apiKey != resultHash {
+	if !matchSignature(apiKey, serviceName, requestAt, nonce, keys) {
 		return errConstant.ErrUnauthorized
 	}
+
+	skew := signatureSkew()
+	err = validateRequestAt(requestAt, skew)
+	if err != nil {
+		return err
+	}
+
+	// Callers send a unique x-request-nonce per request, signed as
+	// sha256(service:key:requestAt:nonce). Callers that still sign without one
+	// fall back to the signature itself, which only allows one request per
+	// second. Timestamps are accepted up to skew on either side of now, so
+	// the nonce has to outlive the whole window.
+	replayKey := fmt.Sprintf("%s:%s:%s", serviceName, requestAt, apiKey)
+	if nonce != "" {
+		replayKey = fmt.Sprintf("%s:nonce:%s", serviceName, nonce)
+	}
+	err = nonceStore.Add(replayKey, struct{}{}, 2*skew)
+	if err != nil {
+		return errConstant.ErrSignatureReplayed
+	}
 	return nil
 }
@@ -0,0 +1,330 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	clientUser "field-service/clients/user"
+	"field-service/common/auth"
+	"field-service/config"
+	"field-service/constants"
+	errConstant "field-service/constants/error"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type stubVerifier struct {
+	claims *auth.Claims
+	err    error
+}
+
+func (s *stubVerifier) Verify(string) (*auth.Claims, error) {
+	return s.claims, s.err
+}
+
+// stubRegistry answers every user-service lookup with the same user and
+// counts the calls.
+type stubRegistry struct {
+	calls int
+}
+
+func (s *stubRegistry) GetUserByToken(context.Context) (*clientUser.UserData, error) {
+	s.calls++
+	return &clientUser.UserData{Username: "remote", Role: "customer"}, nil
+}
+
+func (s *stubRegistry) UserSvc() clientUser.IUserClient {
+	return s
+}
+
+func (s *stubRegistry) UserCache() clientUser.IUserCache {
+	return nil
+}
+
+func newTokenContext() *gin.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx.Request = request.WithContext(context.WithValue(request.Context(), constants.Token, "token"))
+	return ctx
+}
+
+func TestResolveUser(t *testing.T) {
+	local := &auth.Claims{Username: "local", Role: "admin"}
+
+	for _, tc := range []struct {
+		name           string
+		verifier       auth.IVerifier
+		remoteFallback bool
+		username       string
+		err            error
+		remoteCalls    int
+	}{
+		{
+			name:        "no verifier asks user-service",
+			username:    "remote",
+			remoteCalls: 1,
+		},
+		{
+			name:     "verified token stays local",
+			verifier: &stubVerifier{claims: local},
+			username: "local",
+		},
+		{
+			name:           "unknown key falls back to user-service",
+			verifier:       &stubVerifier{err: auth.ErrUnknownKey},
+			remoteFallback: true,
+			username:       "remote",
+			remoteCalls:    1,
+		},
+		{
+			name:     "unknown key without fallback is rejected",
+			verifier: &stubVerifier{err: auth.ErrUnknownKey},
+			err:      auth.ErrUnknownKey,
+		},
+		{
+			name:           "invalid token is never sent to user-service",
+			verifier:       &stubVerifier{err: auth.ErrInvalidToken},
+			remoteFallback: true,
+			err:            auth.ErrInvalidToken,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			SetTokenVerifier(tc.verifier)
+			t.Cleanup(func() { SetTokenVerifier(nil) })
+			config.Config.JWT.RemoteFallback = tc.remoteFallback
+			t.Cleanup(func() { config.Config.JWT.RemoteFallback = false })
+
+			registry := &stubRegistry{}
+			user, err := resolveUser(newTokenContext(), registry)
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("expected %v, got %v", tc.err, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if user.Username != tc.username {
+				t.Errorf("expected user %q, got %q", tc.username, user.Username)
+			}
+
+			if registry.calls != tc.remoteCalls {
+				t.Errorf("expected %d user-service calls, got %d", tc.remoteCalls, registry.calls)
+			}
+		})
+	}
+}
+
+// signRequest sets the headers of a request signed by service with key.
+func signRequest(request *http.Request, service, key string, requestAt time.Time, nonce string) {
+	timestamp := strconv.FormatInt(requestAt.Unix(), 10)
+	validateKey := fmt.Sprintf("%s:%s:%s", service, key, timestamp)
+	if nonce != "" {
+		validateKey = fmt.Sprintf("%s:%s", validateKey, nonce)
+		request.Header.Set(constants.XRequestNonce, nonce)
+	}
+	hash := sha256.Sum256([]byte(validateKey))
+
+	request.Header.Set(constants.XServiceName, service)
+	request.Header.Set(constants.XRequestAt, timestamp)
+	request.Header.Set(constants.XApiKey, hex.EncodeToString(hash[:]))
+}
+
+func newSignedContext(service, key string, requestAt time.Time, nonce string) *gin.Context {
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	signRequest(ctx.Request, service, key, requestAt, nonce)
+	return ctx
+}
+
+// withServiceAuth configures order-service halfway through a key rotation,
+// allowed to call the hold route only.
+func withServiceAuth(t *testing.T) {
+	t.Helper()
+
+	config.Config.SignatureKey = "legacy-key"
+	config.Config.ServiceAuth = map[string]config.ServiceAuth{
+		"order-service": {
+			Keys:   []string{"old-key", "new-key"},
+			Routes: []string{"POST /api/v1/field/schedule/hold"},
+		},
+		"user-service": {Keys: []string{""}},
+	}
+	t.Cleanup(func() {
+		config.Config.SignatureKey = ""
+		config.Config.ServiceAuth = nil
+		nonceStore.Flush()
+	})
+}
+
+func TestValidateApiKeyServiceKeys(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		serviceAuth bool
+		service     string
+		key         string
+		err         error
+	}{
+		{name: "old key during rotation", serviceAuth: true, service: "order-service", key: "old-key"},
+		{name: "new key during rotation", serviceAuth: true, service: "order-service", key: "new-key"},
+		{
+			name:        "removed key",
+			serviceAuth: true,
+			service:     "order-service",
+			key:         "retired-key",
+			err:         errConstant.ErrUnauthorized,
+		},
+		{
+			name:        "unknown service with the legacy key",
+			serviceAuth: true,
+			service:     "made-up-service",
+			key:         "legacy-key",
+			err:         errConstant.ErrUnknownService,
+		},
+		{
+			name:        "listed service without keys",
+			serviceAuth: true,
+			service:     "user-service",
+			key:         "legacy-key",
+			err:         errConstant.ErrUnknownService,
+		},
+		{name: "legacy key without serviceAuth", service: "made-up-service", key: "legacy-key"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			withServiceAuth(t)
+			if !tc.serviceAuth {
+				config.Config.ServiceAuth = nil
+			}
+
+			err := validateApiKey(newSignedContext(tc.service, tc.key, time.Now(), ""))
+			if tc.err == nil && err != nil {
+				t.Fatalf("expected the signature to be accepted, got %v", err)
+			}
+			if tc.err != nil && !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestAuthenticateWithoutTokenChecksRouteAllowList(t *testing.T) {
+	withServiceAuth(t)
+
+	router := gin.New()
+	ok := func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+	router.POST("/api/v1/field/schedule/hold", AuthenticateWithoutToken(), ok)
+	router.POST("/api/v1/promo/quote", AuthenticateWithoutToken(), ok)
+	router.GET("/api/v1/field", Authenticate(), ok)
+
+	for i, tc := range []struct {
+		name    string
+		method  string
+		path    string
+		token   bool
+		status  int
+		message string
+	}{
+		{name: "allowed internal route", method: http.MethodPost, path: "/api/v1/field/schedule/hold", status: http.StatusOK},
+		{
+			name:    "internal route outside the allow-list",
+			method:  http.MethodPost,
+			path:    "/api/v1/promo/quote",
+			status:  http.StatusUnauthorized,
+			message: errConstant.ErrRouteNotAllowed.Error(),
+		},
+		{name: "token route", method: http.MethodGet, path: "/api/v1/field", token: true, status: http.StatusOK},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			request := httptest.NewRequest(tc.method, tc.path, nil)
+			signRequest(request, "order-service", "new-key", time.Now(), fmt.Sprintf("nonce-%d", i))
+			if tc.token {
+				request.Header.Set(constants.Authorization, "Bearer token")
+			}
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+			if recorder.Code != tc.status {
+				t.Fatalf("expected status %d, got %d: %s", tc.status, recorder.Code, recorder.Body)
+			}
+			if tc.message != "" && !strings.Contains(recorder.Body.String(), tc.message) {
+				t.Errorf("expected %q in the response, got %s", tc.message, recorder.Body)
+			}
+		})
+	}
+}
+
+func TestValidateApiKeySkew(t *testing.T) {
+	withServiceAuth(t)
+	config.Config.SignatureSkewSecond = 60
+	t.Cleanup(func() { config.Config.SignatureSkewSecond = 0 })
+
+	for _, tc := range []struct {
+		name   string
+		offset time.Duration
+		err    error
+	}{
+		{name: "just inside the past edge", offset: -59 * time.Second},
+		{name: "just inside the future edge", offset: 59 * time.Second},
+		{name: "just outside the past edge", offset: -61 * time.Second, err: errConstant.ErrSignatureExpired},
+		{name: "just outside the future edge", offset: 61 * time.Second, err: errConstant.ErrSignatureExpired},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateApiKey(newSignedContext("order-service", "new-key", time.Now().Add(tc.offset), ""))
+			if tc.err == nil && err != nil {
+				t.Fatalf("expected the signature to be accepted, got %v", err)
+			}
+			if tc.err != nil && !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestValidateApiKeyReplays(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		nonces [2]string
+		err    error
+	}{
+		{name: "identical request without a nonce", err: errConstant.ErrSignatureReplayed},
+		{
+			name:   "identical request with the same nonce",
+			nonces: [2]string{"nonce-a", "nonce-a"},
+			err:    errConstant.ErrSignatureReplayed,
+		},
+		{name: "distinct nonces in the same second", nonces: [2]string{"nonce-a", "nonce-b"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			withServiceAuth(t)
+			requestAt := time.Now()
+
+			err := validateApiKey(newSignedContext("order-service", "new-key", requestAt, tc.nonces[0]))
+			if err != nil {
+				t.Fatalf("expected the first request to be accepted, got %v", err)
+			}
+
+			err = validateApiKey(newSignedContext("order-service", "new-key", requestAt, tc.nonces[1]))
+			if tc.err == nil && err != nil {
+				t.Fatalf("expected the second request to be accepted, got %v", err)
+			}
+			if tc.err != nil && !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestValidateApiKeyRejectsInvalidNonce(t *testing.T) {
+	withServiceAuth(t)
+
+	err := validateApiKey(newSignedContext("order-service", "new-key", time.Now(), "not a valid nonce"))
+	if !errors.Is(err, errConstant.ErrUnauthorized) {
+		t.Fatalf("expected ErrUnauthorized, got %v", err)
+	}
+}
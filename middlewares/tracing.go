@@ -0,0 +1,58 @@
+package middlewares
+
+import (
+	"field-service/common/tracing"
+	"field-service/constants"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing continues the trace from an incoming traceparent header, or starts
+// a new one, and wraps the request in a server span named after its route.
+func Tracing() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		parent := otel.GetTextMapPropagator().Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+
+		route := routeLabel(ctx)
+		spanCtx, span := tracing.Tracer().Start(parent, fmt.Sprintf("%s %s", ctx.Request.Method, route),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(ctx.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(ctx.Request.URL.Path),
+				semconv.ClientAddress(ctx.ClientIP()),
+			),
+		)
+		defer span.End()
+
+		if requestID, ok := ctx.Get(constants.RequestID); ok {
+			span.SetAttributes(attribute.String("request.id", requestID.(string)))
+		}
+		if serviceName := ctx.GetHeader(constants.XServiceName); serviceName != "" {
+			span.SetAttributes(attribute.String("service.caller", serviceName))
+		}
+
+		ctx.Request = ctx.Request.WithContext(spanCtx)
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if user, ok := GetUserLogin(ctx); ok {
+			span.SetAttributes(attribute.String("user.uuid", user.UUID.String()))
+		}
+		for _, err := range ctx.Errors {
+			span.RecordError(err.Err)
+		}
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
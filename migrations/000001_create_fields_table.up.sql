@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS fields (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL,
+    code VARCHAR(15) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    price_per_hour INT NOT NULL,
+    images TEXT[] NOT NULL,
+    thumbnails TEXT[],
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_fields_uuid ON fields (uuid);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS times (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL,
+    start_time TIME WITHOUT TIME ZONE NOT NULL,
+    end_time TIME WITHOUT TIME ZONE NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_times_uuid ON times (uuid);
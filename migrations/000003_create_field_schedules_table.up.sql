@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS field_schedules (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL,
+    field_id INT NOT NULL REFERENCES fields (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    time_id INT NOT NULL REFERENCES times (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    date DATE NOT NULL,
+    status INT NOT NULL,
+    order_id VARCHAR(100),
+    held_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_field_schedules_uuid ON field_schedules (uuid);
+CREATE INDEX IF NOT EXISTS idx_field_schedules_field_id_date ON field_schedules (field_id, date);
+CREATE INDEX IF NOT EXISTS idx_field_schedules_order_id ON field_schedules (order_id);
+CREATE INDEX IF NOT EXISTS idx_field_schedules_held_until ON field_schedules (held_until) WHERE status = 300;
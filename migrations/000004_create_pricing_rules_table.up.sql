@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS pricing_rules (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL,
+    field_id INT NOT NULL REFERENCES fields (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    days_of_week INT[] NOT NULL,
+    start_time TIME WITHOUT TIME ZONE NOT NULL,
+    end_time TIME WITHOUT TIME ZONE NOT NULL,
+    start_date DATE,
+    end_date DATE,
+    price_per_hour INT NOT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pricing_rules_uuid ON pricing_rules (uuid);
+CREATE INDEX IF NOT EXISTS idx_pricing_rules_field_id ON pricing_rules (field_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS promo_redemptions;
+DROP TABLE IF EXISTS promos;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS promos (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    discount_type VARCHAR(20) NOT NULL,
+    discount_value INT NOT NULL,
+    valid_from TIMESTAMPTZ NOT NULL,
+    valid_until TIMESTAMPTZ NOT NULL,
+    max_usage INT,
+    max_usage_per_user INT,
+    allowed_field_ids TEXT[],
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_promos_uuid ON promos (uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_promos_code ON promos (code);
+
+CREATE TABLE IF NOT EXISTS promo_redemptions (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL,
+    promo_id INT NOT NULL REFERENCES promos (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_id UUID,
+    order_id VARCHAR(100),
+    field_schedule_ids TEXT[] NOT NULL,
+    subtotal INT NOT NULL,
+    discount INT NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_promo_redemptions_promo_id_user_id ON promo_redemptions (promo_id, user_id);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS processed_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(100) NOT NULL,
+    subject VARCHAR(100) NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_processed_events_event_id ON processed_events (event_id);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    uuid UUID NOT NULL,
+    subject VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_events_uuid ON outbox_events (uuid);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (next_attempt_at, id) WHERE sent_at IS NULL;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_field_schedules_slot;
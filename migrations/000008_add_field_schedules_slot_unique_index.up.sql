@@ -0,0 +1,22 @@
+-- Soft delete duplicate slots before adding the index, keeping the booked or
+-- held row when there is one and the oldest row otherwise.
+UPDATE field_schedules
+SET deleted_at = NOW()
+WHERE id IN (
+    SELECT id
+    FROM (
+        SELECT
+            id,
+            ROW_NUMBER() OVER (
+                PARTITION BY field_id, date, time_id
+                ORDER BY CASE status WHEN 200 THEN 0 WHEN 300 THEN 1 ELSE 2 END, id
+            ) AS position
+        FROM field_schedules
+        WHERE deleted_at IS NULL
+    ) AS ranked
+    WHERE position > 1
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_field_schedules_slot
+    ON field_schedules (field_id, date, time_id)
+    WHERE deleted_at IS NULL;
@@ -0,0 +1,270 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// lockID serialises migration runs across replicas through a transaction
+// scoped advisory lock.
+const lockID = 7263541098
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+type schemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"type:varchar(255);not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+type IMigrator interface {
+	Up(context.Context) ([]Migration, error)
+	Down(context.Context, int) ([]Migration, error)
+	Status(context.Context) ([]Status, error)
+	Pending(context.Context) ([]Migration, error)
+}
+
+func NewMigrator(db *gorm.DB) (IMigrator, error) {
+	migrations, err := load(files)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// load pairs up NNNNNN_name.up.sql and NNNNNN_name.down.sql files sorted by
+// version. Every version needs both directions.
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		matches := fileNamePattern.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(".", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: matches[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != matches[2] {
+			return nil, fmt.Errorf("migration %d has mismatched names %q and %q", version, migration.Name, matches[2])
+		}
+
+		if matches[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s is missing its up or down file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	return m.db.WithContext(ctx).AutoMigrate(&schemaMigration{})
+}
+
+func (m *Migrator) applied(ctx context.Context, tx *gorm.DB) (map[int64]time.Time, error) {
+	var rows []schemaMigration
+	err := tx.WithContext(ctx).Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]time.Time, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+	}
+
+	return applied, nil
+}
+
+// Up applies every pending migration in order, each in its own transaction,
+// and returns the ones it applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	err := m.ensureTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make([]Migration, 0)
+	for _, migration := range m.migrations {
+		applied := false
+		err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			err := tx.Exec("SELECT pg_advisory_xact_lock(?)", lockID).Error
+			if err != nil {
+				return err
+			}
+
+			var count int64
+			err = tx.Model(&schemaMigration{}).Where("version = ?", migration.Version).Count(&count).Error
+			if err != nil || count > 0 {
+				return err
+			}
+
+			err = tx.Exec(migration.Up).Error
+			if err != nil {
+				return fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+			}
+
+			applied = true
+			return tx.Create(&schemaMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return done, err
+		}
+
+		if applied {
+			done = append(done, migration)
+		}
+	}
+
+	return done, nil
+}
+
+// Down rolls back the latest steps applied migrations, newest first.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	err := m.ensureTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	done := make([]Migration, 0, steps)
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		reverted := false
+		err = m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			err := tx.Exec("SELECT pg_advisory_xact_lock(?)", lockID).Error
+			if err != nil {
+				return err
+			}
+
+			result := tx.Where("version = ?", migration.Version).Delete(&schemaMigration{})
+			if result.Error != nil || result.RowsAffected == 0 {
+				return result.Error
+			}
+
+			err = tx.Exec(migration.Down).Error
+			if err != nil {
+				return fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+			}
+
+			reverted = true
+			return nil
+		})
+		if err != nil {
+			return done, err
+		}
+
+		if reverted {
+			done = append(done, migration)
+		}
+	}
+
+	return done, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	err := m.ensureTable(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := m.applied(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]Migration, 0)
+	for i, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, m.migrations[i])
+		}
+	}
+
+	return pending, nil
+}
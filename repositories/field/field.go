@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errField "field-service/constants/error/field"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type FieldRepository struct {
+	db *gorm.DB
+}
+
+type IFieldRepository interface {
+	FindAllWithPagination(context.Context, *dto.FieldRequestParam) ([]models.Field, int64, error)
+	FindByUUID(context.Context, string) (*models.Field, error)
+	Create(context.Context, *models.Field) (*models.Field, error)
+	Update(context.Context, string, *models.Field) (*models.Field, error)
+	Delete(context.Context, *gorm.DB, string) error
+}
+
+var fieldSortColumns = map[string]string{
+	"code":         "code",
+	"name":         "name",
+	"pricePerHour": "price_per_hour",
+	"createdAt":    "created_at",
+	"updatedAt":    "updated_at",
+}
+
+func NewFieldRepository(db *gorm.DB) IFieldRepository {
+	return &FieldRepository{db: db}
+}
+
+func (f *FieldRepository) FindAllWithPagination(
+	ctx context.Context,
+	param *dto.FieldRequestParam,
+) ([]models.Field, int64, error) {
+	var (
+		fields []models.Field
+		sort   string
+		total  int64
+	)
+
+	if param.SortColumn != nil {
+		column, ok := fieldSortColumns[*param.SortColumn]
+		if !ok {
+			return nil, 0, errWrap.WrapErr(ctx, errConstant.ErrInvalidSortColumn)
+		}
+
+		order := "asc"
+		if param.SortOrder != nil && strings.EqualFold(*param.SortOrder, "desc") {
+			order = "desc"
+		}
+		sort = fmt.Sprintf("%s %s", column, order)
+	} else {
+		sort = "created_at desc"
+	}
+
+	limit := param.Limit
+	offset := (param.Page - 1) * limit
+	err := f.db.
+		WithContext(ctx).
+		Limit(limit).
+		Offset(offset).
+		Order(sort).
+		Where("deleted_at IS NULL").
+		Find(&fields).
+		Error
+	if err != nil {
+		return nil, 0, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	err = f.db.
+		WithContext(ctx).
+		Model(&models.Field{}).
+		Where("deleted_at IS NULL").
+		Count(&total).
+		Error
+	if err != nil {
+		return nil, 0, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return fields, total, nil
+}
+
+func (f *FieldRepository) FindByUUID(ctx context.Context, uuid string) (*models.Field, error) {
+	var field models.Field
+	err := f.db.
+		WithContext(ctx).
+		Where("uuid = ?", uuid).
+		Where("deleted_at IS NULL").
+		First(&field).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapErr(ctx, errField.ErrFieldNotFound)
+		}
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return &field, nil
+}
+
+func (f *FieldRepository) Create(ctx context.Context, req *models.Field) (*models.Field, error) {
+	field := models.Field{
+		UUID:         req.UUID,
+		Code:         req.Code,
+		Name:         req.Name,
+		PricePerHour: req.PricePerHour,
+		Images:       req.Images,
+		Thumbnails:   req.Thumbnails,
+	}
+
+	err := f.db.WithContext(ctx).Create(&field).Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return &field, nil
+}
+
+func (f *FieldRepository) Update(ctx context.Context, uuid string, req *models.Field) (*models.Field, error) {
+	field := models.Field{
+		Code:         req.Code,
+		Name:         req.Name,
+		PricePerHour: req.PricePerHour,
+		Images:       req.Images,
+		Thumbnails:   req.Thumbnails,
+	}
+
+	err := f.db.
+		WithContext(ctx).
+		Where("uuid = ?", uuid).
+		Where("deleted_at IS NULL").
+		Updates(&field).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return f.FindByUUID(ctx, uuid)
+}
+
+// Delete soft deletes the field. Hard deleting it would cascade to its
+// schedules and pricing rules, including the history of past bookings.
+func (f *FieldRepository) Delete(ctx context.Context, tx *gorm.DB, uuid string) error {
+	now := time.Now()
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Field{}).
+		Where("uuid = ?", uuid).
+		Where("deleted_at IS NULL").
+		Updates(map[string]any{
+			"deleted_at": now,
+			"updated_at": now,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return nil
+}
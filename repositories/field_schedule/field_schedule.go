@@ -0,0 +1,371 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	"field-service/constants"
+	errConstant "field-service/constants/error"
+	errFieldSchedule "field-service/constants/error/field_schedule"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// uniqueViolation is the Postgres error code raised when a row would break a
+// unique index, here two live schedules for the same field, date and time.
+const uniqueViolation = "23505"
+
+type FieldScheduleRepository struct {
+	db *gorm.DB
+}
+
+type IFieldScheduleRepository interface {
+	FindAllByFieldIDAndDate(context.Context, uint, string) ([]models.FieldSchedule, error)
+	FindByUUID(context.Context, string) (*models.FieldSchedule, error)
+	FindDatesByFieldIDAndDateRange(context.Context, *gorm.DB, uint, time.Time, time.Time) ([]time.Time, error)
+	FindByUUIDs(context.Context, *gorm.DB, []string) ([]models.FieldSchedule, error)
+	FindByUUIDsForUpdate(context.Context, *gorm.DB, []string) ([]models.FieldSchedule, error)
+	Create(context.Context, *gorm.DB, []models.FieldSchedule) error
+	UpdateStatus(context.Context, *gorm.DB, constants.FieldScheduleStatus, *string, []string) error
+	Hold(context.Context, *gorm.DB, string, time.Time, []string) error
+	ReleaseByOrderID(context.Context, *gorm.DB, string) ([]string, error)
+	ReleaseByOrderIDAndUUIDs(context.Context, *gorm.DB, string, []string) ([]string, error)
+	ReleaseExpiredHolds(context.Context, *gorm.DB, time.Time) ([]string, error)
+	FindUUIDsByFieldID(context.Context, *gorm.DB, uint) ([]string, error)
+	FindFromDateByFieldIDForUpdate(context.Context, *gorm.DB, uint, time.Time) ([]models.FieldSchedule, error)
+	SoftDeleteByFieldID(context.Context, *gorm.DB, uint, time.Time) error
+	CountByStatusForDate(context.Context, time.Time, time.Time) ([]dto.FieldScheduleStatusCount, error)
+}
+
+func NewFieldScheduleRepository(db *gorm.DB) IFieldScheduleRepository {
+	return &FieldScheduleRepository{db: db}
+}
+
+func (f *FieldScheduleRepository) FindAllByFieldIDAndDate(
+	ctx context.Context,
+	fieldID uint,
+	date string,
+) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := f.db.
+		WithContext(ctx).
+		Preload("Field").
+		Preload("Time").
+		Joins("JOIN times ON times.id = field_schedules.time_id").
+		Where("field_schedules.field_id = ?", fieldID).
+		Where("field_schedules.date = ?", date).
+		Where("field_schedules.deleted_at IS NULL").
+		Order("times.start_time asc").
+		Find(&fieldSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
+func (f *FieldScheduleRepository) FindByUUID(ctx context.Context, uuid string) (*models.FieldSchedule, error) {
+	var fieldSchedule models.FieldSchedule
+	err := f.db.
+		WithContext(ctx).
+		Preload("Field").
+		Preload("Time").
+		Where("uuid = ?", uuid).
+		Where("deleted_at IS NULL").
+		First(&fieldSchedule).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapErr(ctx, errFieldSchedule.ErrFieldScheduleNotFound)
+		}
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return &fieldSchedule, nil
+}
+
+func (f *FieldScheduleRepository) FindDatesByFieldIDAndDateRange(
+	ctx context.Context,
+	tx *gorm.DB,
+	fieldID uint,
+	startDate, endDate time.Time,
+) ([]time.Time, error) {
+	var dates []time.Time
+	err := tx.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Distinct("date").
+		Where("field_id = ?", fieldID).
+		Where("date BETWEEN ? AND ?", startDate, endDate).
+		Where("deleted_at IS NULL").
+		Pluck("date", &dates).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return dates, nil
+}
+
+func (f *FieldScheduleRepository) FindByUUIDs(
+	ctx context.Context,
+	tx *gorm.DB,
+	uuids []string,
+) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := tx.
+		WithContext(ctx).
+		Preload("Field").
+		Preload("Time").
+		Where("uuid IN ?", uuids).
+		Where("deleted_at IS NULL").
+		Order("date asc").
+		Order("id asc").
+		Find(&fieldSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
+// FindByUUIDsForUpdate locks the matching rows until tx ends. Rows are locked
+// in id order so concurrent callers never wait on each other in a cycle.
+func (f *FieldScheduleRepository) FindByUUIDsForUpdate(
+	ctx context.Context,
+	tx *gorm.DB,
+	uuids []string,
+) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("uuid IN ?", uuids).
+		Where("deleted_at IS NULL").
+		Order("id asc").
+		Find(&fieldSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
+func (f *FieldScheduleRepository) Create(ctx context.Context, tx *gorm.DB, req []models.FieldSchedule) error {
+	err := tx.WithContext(ctx).Create(&req).Error
+	if err != nil {
+		if isUniqueViolation(err) {
+			return errWrap.WrapErr(ctx, errFieldSchedule.ErrFieldScheduleIsExist)
+		}
+		return errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (f *FieldScheduleRepository) UpdateStatus(
+	ctx context.Context,
+	tx *gorm.DB,
+	status constants.FieldScheduleStatus,
+	orderID *string,
+	uuids []string,
+) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Where("uuid IN ?", uuids).
+		Updates(map[string]any{
+			"status":     status,
+			"order_id":   orderID,
+			"held_until": nil,
+			"updated_at": time.Now(),
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (f *FieldScheduleRepository) Hold(
+	ctx context.Context,
+	tx *gorm.DB,
+	orderID string,
+	heldUntil time.Time,
+	uuids []string,
+) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Where("uuid IN ?", uuids).
+		Updates(map[string]any{
+			"status":     constants.Held,
+			"order_id":   orderID,
+			"held_until": heldUntil,
+			"updated_at": time.Now(),
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// release returns the schedules matched by query to available and reports
+// the uuids that were changed.
+func release(ctx context.Context, query *gorm.DB, now time.Time) ([]string, error) {
+	var released []models.FieldSchedule
+	err := query.
+		WithContext(ctx).
+		Model(&released).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "uuid"}}}).
+		Updates(map[string]any{
+			"status":     constants.Available,
+			"order_id":   nil,
+			"held_until": nil,
+			"updated_at": now,
+		}).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	uuids := make([]string, 0, len(released))
+	for _, fieldSchedule := range released {
+		uuids = append(uuids, fieldSchedule.UUID.String())
+	}
+
+	return uuids, nil
+}
+
+func (f *FieldScheduleRepository) ReleaseByOrderID(ctx context.Context, tx *gorm.DB, orderID string) ([]string, error) {
+	query := tx.
+		Where("order_id = ?", orderID).
+		Where("status = ?", constants.Held)
+	return release(ctx, query, time.Now())
+}
+
+// ReleaseByOrderIDAndUUIDs returns the given schedules to available, held or
+// booked alike, but only while they still belong to orderID.
+func (f *FieldScheduleRepository) ReleaseByOrderIDAndUUIDs(
+	ctx context.Context,
+	tx *gorm.DB,
+	orderID string,
+	uuids []string,
+) ([]string, error) {
+	query := tx.
+		Where("uuid IN ?", uuids).
+		Where("order_id = ?", orderID).
+		Where("status IN ?", []constants.FieldScheduleStatus{constants.Held, constants.Booked})
+	return release(ctx, query, time.Now())
+}
+
+func (f *FieldScheduleRepository) ReleaseExpiredHolds(ctx context.Context, tx *gorm.DB, now time.Time) ([]string, error) {
+	query := tx.
+		Where("status = ?", constants.Held).
+		Where("held_until < ?", now)
+	return release(ctx, query, now)
+}
+
+func (f *FieldScheduleRepository) FindUUIDsByFieldID(ctx context.Context, tx *gorm.DB, fieldID uint) ([]string, error) {
+	var uuids []string
+	err := tx.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Where("field_id = ?", fieldID).
+		Where("deleted_at IS NULL").
+		Pluck("uuid", &uuids).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return uuids, nil
+}
+
+// FindFromDateByFieldIDForUpdate locks the live schedules of a field from date
+// on, so no order can hold or book them until tx ends.
+func (f *FieldScheduleRepository) FindFromDateByFieldIDForUpdate(
+	ctx context.Context,
+	tx *gorm.DB,
+	fieldID uint,
+	date time.Time,
+) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("field_id = ?", fieldID).
+		Where("date >= ?", date.Format(time.DateOnly)).
+		Where("deleted_at IS NULL").
+		Order("id asc").
+		Find(&fieldSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
+func (f *FieldScheduleRepository) SoftDeleteByFieldID(ctx context.Context, tx *gorm.DB, fieldID uint, now time.Time) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Where("field_id = ?", fieldID).
+		Where("deleted_at IS NULL").
+		Updates(map[string]any{
+			"deleted_at": now,
+			"updated_at": now,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// CountByStatusForDate counts the live schedules of every field on date by
+// status. Holds that expired before now are counted as available, the same
+// way they are shown to customers.
+func (f *FieldScheduleRepository) CountByStatusForDate(
+	ctx context.Context,
+	date time.Time,
+	now time.Time,
+) ([]dto.FieldScheduleStatusCount, error) {
+	var counts []dto.FieldScheduleStatusCount
+	err := f.db.
+		WithContext(ctx).
+		Table("field_schedules").
+		Select(`fields.uuid AS field_uuid, fields.code AS field_code,
+			CASE WHEN field_schedules.status = ? AND field_schedules.held_until <= ? THEN ?
+			ELSE field_schedules.status END AS status,
+			COUNT(*) AS total`, constants.Held, now, constants.Available).
+		Joins("JOIN fields ON fields.id = field_schedules.field_id AND fields.deleted_at IS NULL").
+		Where("field_schedules.date = ?", date.Format(time.DateOnly)).
+		Where("field_schedules.deleted_at IS NULL").
+		Group("fields.id, fields.uuid, fields.code, 3").
+		Scan(&counts).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return counts, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolation
+}
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	"field-service/domain/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+type IOutboxRepository interface {
+	Create(context.Context, *gorm.DB, []models.OutboxEvent) error
+	FindPendingForUpdate(context.Context, *gorm.DB, time.Time, int) ([]models.OutboxEvent, error)
+	MarkSent(context.Context, *gorm.DB, []uint, time.Time) error
+	MarkFailed(context.Context, *gorm.DB, uint, string, time.Time) error
+	DeleteSentBefore(context.Context, time.Time) (int64, error)
+}
+
+func NewOutboxRepository(db *gorm.DB) IOutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+func (o *OutboxRepository) Create(ctx context.Context, tx *gorm.DB, req []models.OutboxEvent) error {
+	if len(req) == 0 {
+		return nil
+	}
+
+	err := tx.WithContext(ctx).Create(&req).Error
+	if err != nil {
+		return errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// FindPendingForUpdate locks the oldest unsent events that are due. Rows
+// locked by another relay are skipped so replicas never publish the same row
+// concurrently.
+func (o *OutboxRepository) FindPendingForUpdate(
+	ctx context.Context,
+	tx *gorm.DB,
+	now time.Time,
+	limit int,
+) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("sent_at IS NULL").
+		Where("next_attempt_at <= ?", now).
+		Order("id asc").
+		Limit(limit).
+		Find(&events).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return events, nil
+}
+
+func (o *OutboxRepository) MarkSent(ctx context.Context, tx *gorm.DB, ids []uint, sentAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := tx.
+		WithContext(ctx).
+		Model(&models.OutboxEvent{}).
+		Where("id IN ?", ids).
+		Updates(map[string]any{
+			"sent_at":    sentAt,
+			"last_error": nil,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (o *OutboxRepository) MarkFailed(
+	ctx context.Context,
+	tx *gorm.DB,
+	id uint,
+	lastError string,
+	nextAttemptAt time.Time,
+) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      lastError,
+			"next_attempt_at": nextAttemptAt,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (o *OutboxRepository) DeleteSentBefore(ctx context.Context, sentBefore time.Time) (int64, error) {
+	result := o.db.
+		WithContext(ctx).
+		Where("sent_at < ?", sentBefore).
+		Delete(&models.OutboxEvent{})
+	if result.Error != nil {
+		return 0, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errPricingRule "field-service/constants/error/pricing_rule"
+	"field-service/domain/models"
+
+	"gorm.io/gorm"
+)
+
+type PricingRuleRepository struct {
+	db *gorm.DB
+}
+
+type IPricingRuleRepository interface {
+	FindAll(context.Context, *uint) ([]models.PricingRule, error)
+	FindAllByFieldID(context.Context, uint) ([]models.PricingRule, error)
+	FindByUUID(context.Context, string) (*models.PricingRule, error)
+	Create(context.Context, *models.PricingRule) (*models.PricingRule, error)
+	Update(context.Context, string, *models.PricingRule) (*models.PricingRule, error)
+	Delete(context.Context, string) error
+}
+
+func NewPricingRuleRepository(db *gorm.DB) IPricingRuleRepository {
+	return &PricingRuleRepository{db: db}
+}
+
+func (p *PricingRuleRepository) FindAll(ctx context.Context, fieldID *uint) ([]models.PricingRule, error) {
+	var pricingRules []models.PricingRule
+	query := p.db.
+		WithContext(ctx).
+		Preload("Field")
+	if fieldID != nil {
+		query = query.Where("field_id = ?", *fieldID)
+	}
+
+	err := query.
+		Order("field_id asc").
+		Order("priority desc").
+		Order("id desc").
+		Find(&pricingRules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return pricingRules, nil
+}
+
+// FindAllByFieldID returns the rules of one field in the order they must be
+// evaluated: highest priority first, newest first on ties.
+func (p *PricingRuleRepository) FindAllByFieldID(ctx context.Context, fieldID uint) ([]models.PricingRule, error) {
+	var pricingRules []models.PricingRule
+	err := p.db.
+		WithContext(ctx).
+		Where("field_id = ?", fieldID).
+		Order("priority desc").
+		Order("id desc").
+		Find(&pricingRules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return pricingRules, nil
+}
+
+func (p *PricingRuleRepository) FindByUUID(ctx context.Context, uuid string) (*models.PricingRule, error) {
+	var pricingRule models.PricingRule
+	err := p.db.
+		WithContext(ctx).
+		Preload("Field").
+		Where("uuid = ?", uuid).
+		First(&pricingRule).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapErr(ctx, errPricingRule.ErrPricingRuleNotFound)
+		}
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return &pricingRule, nil
+}
+
+func (p *PricingRuleRepository) Create(ctx context.Context, req *models.PricingRule) (*models.PricingRule, error) {
+	pricingRule := models.PricingRule{
+		UUID:         req.UUID,
+		FieldID:      req.FieldID,
+		Name:         req.Name,
+		DaysOfWeek:   req.DaysOfWeek,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		PricePerHour: req.PricePerHour,
+		Priority:     req.Priority,
+	}
+
+	err := p.db.WithContext(ctx).Create(&pricingRule).Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return p.FindByUUID(ctx, pricingRule.UUID.String())
+}
+
+func (p *PricingRuleRepository) Update(
+	ctx context.Context,
+	uuid string,
+	req *models.PricingRule,
+) (*models.PricingRule, error) {
+	err := p.db.
+		WithContext(ctx).
+		Model(&models.PricingRule{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]any{
+			"field_id":       req.FieldID,
+			"name":           req.Name,
+			"days_of_week":   req.DaysOfWeek,
+			"start_time":     req.StartTime,
+			"end_time":       req.EndTime,
+			"start_date":     req.StartDate,
+			"end_date":       req.EndDate,
+			"price_per_hour": req.PricePerHour,
+			"priority":       req.Priority,
+		}).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return p.FindByUUID(ctx, uuid)
+}
+
+func (p *PricingRuleRepository) Delete(ctx context.Context, uuid string) error {
+	err := p.db.
+		WithContext(ctx).
+		Where("uuid = ?", uuid).
+		Delete(&models.PricingRule{}).
+		Error
+	if err != nil {
+		return errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	"field-service/domain/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProcessedEventRepository struct {
+	db *gorm.DB
+}
+
+type IProcessedEventRepository interface {
+	Create(context.Context, *gorm.DB, *models.ProcessedEvent) (bool, error)
+}
+
+func NewProcessedEventRepository(db *gorm.DB) IProcessedEventRepository {
+	return &ProcessedEventRepository{db: db}
+}
+
+// Create marks the event as processed and reports false when the event id
+// was already recorded.
+func (p *ProcessedEventRepository) Create(
+	ctx context.Context,
+	tx *gorm.DB,
+	event *models.ProcessedEvent,
+) (bool, error) {
+	result := tx.
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "event_id"}},
+			DoNothing: true,
+		}).
+		Create(event)
+	if result.Error != nil {
+		return false, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return result.RowsAffected > 0, nil
+}
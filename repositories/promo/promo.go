@@ -0,0 +1,228 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errPromo "field-service/constants/error/promo"
+	"field-service/domain/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PromoRepository struct {
+	db *gorm.DB
+}
+
+type IPromoRepository interface {
+	FindAll(context.Context) ([]models.Promo, error)
+	FindByUUID(context.Context, string) (*models.Promo, error)
+	FindByUUIDForUpdate(context.Context, *gorm.DB, string) (*models.Promo, error)
+	FindByCode(context.Context, *gorm.DB, string, bool) (*models.Promo, error)
+	Create(context.Context, *models.Promo) (*models.Promo, error)
+	Update(context.Context, string, *models.Promo) (*models.Promo, error)
+	Delete(context.Context, *gorm.DB, uint) error
+	End(context.Context, *gorm.DB, uint, time.Time) error
+	CountRedemptions(context.Context, *gorm.DB, uint, *uuid.UUID) (int64, error)
+	CreateRedemption(context.Context, *gorm.DB, *models.PromoRedemption) error
+}
+
+func NewPromoRepository(db *gorm.DB) IPromoRepository {
+	return &PromoRepository{db: db}
+}
+
+func (p *PromoRepository) FindAll(ctx context.Context) ([]models.Promo, error) {
+	var promos []models.Promo
+	err := p.db.
+		WithContext(ctx).
+		Order("created_at desc").
+		Find(&promos).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return promos, nil
+}
+
+func (p *PromoRepository) FindByUUID(ctx context.Context, uuid string) (*models.Promo, error) {
+	var promo models.Promo
+	err := p.db.
+		WithContext(ctx).
+		Where("uuid = ?", uuid).
+		First(&promo).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapErr(ctx, errPromo.ErrPromoNotFound)
+		}
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return &promo, nil
+}
+
+// FindByUUIDForUpdate locks the promo until tx ends, so no redemption of it
+// can be created in the meantime.
+func (p *PromoRepository) FindByUUIDForUpdate(ctx context.Context, tx *gorm.DB, uuid string) (*models.Promo, error) {
+	var promo models.Promo
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("uuid = ?", uuid).
+		First(&promo).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapErr(ctx, errPromo.ErrPromoNotFound)
+		}
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return &promo, nil
+}
+
+// FindByCode looks a promo up by its code. When forUpdate is set the row stays
+// locked until tx ends, which serialises concurrent redemptions of one code.
+func (p *PromoRepository) FindByCode(
+	ctx context.Context,
+	tx *gorm.DB,
+	code string,
+	forUpdate bool,
+) (*models.Promo, error) {
+	var promo models.Promo
+	query := tx.WithContext(ctx)
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	err := query.
+		Where("code = ?", code).
+		First(&promo).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapErr(ctx, errPromo.ErrPromoNotFound)
+		}
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return &promo, nil
+}
+
+func (p *PromoRepository) Create(ctx context.Context, req *models.Promo) (*models.Promo, error) {
+	promo := models.Promo{
+		UUID:            req.UUID,
+		Code:            req.Code,
+		Name:            req.Name,
+		DiscountType:    req.DiscountType,
+		DiscountValue:   req.DiscountValue,
+		ValidFrom:       req.ValidFrom,
+		ValidUntil:      req.ValidUntil,
+		MaxUsage:        req.MaxUsage,
+		MaxUsagePerUser: req.MaxUsagePerUser,
+		AllowedFieldIDs: req.AllowedFieldIDs,
+	}
+
+	err := p.db.WithContext(ctx).Create(&promo).Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return &promo, nil
+}
+
+func (p *PromoRepository) Update(ctx context.Context, uuid string, req *models.Promo) (*models.Promo, error) {
+	err := p.db.
+		WithContext(ctx).
+		Model(&models.Promo{}).
+		Where("uuid = ?", uuid).
+		Updates(map[string]any{
+			"code":               req.Code,
+			"name":               req.Name,
+			"discount_type":      req.DiscountType,
+			"discount_value":     req.DiscountValue,
+			"valid_from":         req.ValidFrom,
+			"valid_until":        req.ValidUntil,
+			"max_usage":          req.MaxUsage,
+			"max_usage_per_user": req.MaxUsagePerUser,
+			"allowed_field_ids":  req.AllowedFieldIDs,
+		}).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return p.FindByUUID(ctx, uuid)
+}
+
+func (p *PromoRepository) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	err := tx.
+		WithContext(ctx).
+		Where("id = ?", id).
+		Delete(&models.Promo{}).
+		Error
+	if err != nil {
+		return errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// End stops a promo from being redeemed after at by moving valid_until back
+// to it. A promo that already ended earlier is left as is.
+func (p *PromoRepository) End(ctx context.Context, tx *gorm.DB, id uint, at time.Time) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Promo{}).
+		Where("id = ?", id).
+		Where("valid_until > ?", at).
+		Updates(map[string]any{
+			"valid_until": at,
+			"updated_at":  at,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// CountRedemptions counts how often a promo has been redeemed, by everyone
+// when userID is nil or by that user otherwise.
+func (p *PromoRepository) CountRedemptions(
+	ctx context.Context,
+	tx *gorm.DB,
+	promoID uint,
+	userID *uuid.UUID,
+) (int64, error) {
+	var total int64
+	query := tx.
+		WithContext(ctx).
+		Model(&models.PromoRedemption{}).
+		Where("promo_id = ?", promoID)
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return 0, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return total, nil
+}
+
+func (p *PromoRepository) CreateRedemption(ctx context.Context, tx *gorm.DB, req *models.PromoRedemption) error {
+	err := tx.WithContext(ctx).Create(req).Error
+	if err != nil {
+		return errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	fieldRepo "field-service/repositories/field"
+	fieldScheduleRepo "field-service/repositories/field_schedule"
+	outboxRepo "field-service/repositories/outbox"
+	pricingRuleRepo "field-service/repositories/pricing_rule"
+	processedEventRepo "field-service/repositories/processed_event"
+	promoRepo "field-service/repositories/promo"
+	timeRepo "field-service/repositories/time"
+
+	"gorm.io/gorm"
+)
+
+type Registry struct {
+	db *gorm.DB
+}
+
+type IRepositoryRegistry interface {
+	GetField() fieldRepo.IFieldRepository
+	GetFieldSchedule() fieldScheduleRepo.IFieldScheduleRepository
+	GetOutbox() outboxRepo.IOutboxRepository
+	GetPricingRule() pricingRuleRepo.IPricingRuleRepository
+	GetProcessedEvent() processedEventRepo.IProcessedEventRepository
+	GetPromo() promoRepo.IPromoRepository
+	GetTime() timeRepo.ITimeRepository
+	GetTx() *gorm.DB
+}
+
+func NewRepositoryRegistry(db *gorm.DB) IRepositoryRegistry {
+	return &Registry{db: db}
+}
+
+func (r *Registry) GetField() fieldRepo.IFieldRepository {
+	return fieldRepo.NewFieldRepository(r.db)
+}
+
+func (r *Registry) GetFieldSchedule() fieldScheduleRepo.IFieldScheduleRepository {
+	return fieldScheduleRepo.NewFieldScheduleRepository(r.db)
+}
+
+func (r *Registry) GetOutbox() outboxRepo.IOutboxRepository {
+	return outboxRepo.NewOutboxRepository(r.db)
+}
+
+func (r *Registry) GetPricingRule() pricingRuleRepo.IPricingRuleRepository {
+	return pricingRuleRepo.NewPricingRuleRepository(r.db)
+}
+
+func (r *Registry) GetProcessedEvent() processedEventRepo.IProcessedEventRepository {
+	return processedEventRepo.NewProcessedEventRepository(r.db)
+}
+
+func (r *Registry) GetPromo() promoRepo.IPromoRepository {
+	return promoRepo.NewPromoRepository(r.db)
+}
+
+func (r *Registry) GetTime() timeRepo.ITimeRepository {
+	return timeRepo.NewTimeRepository(r.db)
+}
+
+func (r *Registry) GetTx() *gorm.DB {
+	return r.db
+}
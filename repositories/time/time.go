@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errTime "field-service/constants/error/time"
+	"field-service/domain/models"
+
+	"gorm.io/gorm"
+)
+
+type TimeRepository struct {
+	db *gorm.DB
+}
+
+type ITimeRepository interface {
+	FindAll(context.Context) ([]models.Time, error)
+	FindByUUID(context.Context, string) (*models.Time, error)
+	FindOverlap(context.Context, string, string, *string) (*models.Time, error)
+	Create(context.Context, *models.Time) (*models.Time, error)
+	Update(context.Context, string, *models.Time) (*models.Time, error)
+}
+
+func NewTimeRepository(db *gorm.DB) ITimeRepository {
+	return &TimeRepository{db: db}
+}
+
+func (t *TimeRepository) FindAll(ctx context.Context) ([]models.Time, error) {
+	var times []models.Time
+	err := t.db.
+		WithContext(ctx).
+		Order("start_time asc").
+		Find(&times).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return times, nil
+}
+
+func (t *TimeRepository) FindByUUID(ctx context.Context, uuid string) (*models.Time, error) {
+	var time models.Time
+	err := t.db.
+		WithContext(ctx).
+		Where("uuid = ?", uuid).
+		First(&time).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapErr(ctx, errTime.ErrTimeNotFound)
+		}
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return &time, nil
+}
+
+// FindOverlap returns the first slot whose range intersects [startTime, endTime),
+// ignoring the slot identified by excludeUUID when it is set.
+func (t *TimeRepository) FindOverlap(
+	ctx context.Context,
+	startTime, endTime string,
+	excludeUUID *string,
+) (*models.Time, error) {
+	var time models.Time
+	query := t.db.
+		WithContext(ctx).
+		Where("start_time < ? AND end_time > ?", endTime, startTime)
+	if excludeUUID != nil {
+		query = query.Where("uuid <> ?", *excludeUUID)
+	}
+
+	err := query.First(&time).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return &time, nil
+}
+
+func (t *TimeRepository) Create(ctx context.Context, req *models.Time) (*models.Time, error) {
+	time := models.Time{
+		UUID:      req.UUID,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	}
+
+	err := t.db.WithContext(ctx).Create(&time).Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return &time, nil
+}
+
+func (t *TimeRepository) Update(ctx context.Context, uuid string, req *models.Time) (*models.Time, error) {
+	time := models.Time{
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	}
+
+	err := t.db.
+		WithContext(ctx).
+		Where("uuid = ?", uuid).
+		Updates(&time).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errConstant.ErrSQLError)
+	}
+
+	return t.FindByUUID(ctx, uuid)
+}
@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FieldScheduleRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IFieldScheduleRoute interface {
+	Run()
+}
+
+func NewFieldScheduleRoute(
+	controller controllers.IControllerRegistry,
+	group *gin.RouterGroup,
+	client clients.IClientRegistry,
+) IFieldScheduleRoute {
+	return &FieldScheduleRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (f *FieldScheduleRoute) Run() {
+	group := f.group.Group("/field/schedule")
+	group.GET("/lists/:uuid", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().GetAllByFieldIDAndDate)
+	group.PATCH("/status", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().UpdateStatus)
+	group.POST("/hold", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().Hold)
+	group.POST("/release", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().Release)
+	group.Use(middlewares.Authenticate())
+	group.GET("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+		constants.Customer,
+	}, f.client), f.controller.GetFieldSchedule().GetByUUID)
+	group.POST("/one-month", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, f.client), f.controller.GetFieldSchedule().GenerateScheduleForOneMonth)
+}
@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PricingRuleRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IPricingRuleRoute interface {
+	Run()
+}
+
+func NewPricingRuleRoute(
+	controller controllers.IControllerRegistry,
+	group *gin.RouterGroup,
+	client clients.IClientRegistry,
+) IPricingRuleRoute {
+	return &PricingRuleRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (p *PricingRuleRoute) Run() {
+	group := p.group.Group("/pricing-rule")
+	group.Use(middlewares.Authenticate())
+	group.Use(middlewares.CheckRole([]string{
+		constants.Admin,
+	}, p.client))
+	group.GET("", p.controller.GetPricingRule().GetAll)
+	group.GET("/:uuid", p.controller.GetPricingRule().GetByUUID)
+	group.POST("", p.controller.GetPricingRule().Create)
+	group.PUT("/:uuid", p.controller.GetPricingRule().Update)
+	group.DELETE("/:uuid", p.controller.GetPricingRule().Delete)
+}
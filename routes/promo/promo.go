@@ -0,0 +1,46 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromoRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IPromoRoute interface {
+	Run()
+}
+
+func NewPromoRoute(
+	controller controllers.IControllerRegistry,
+	group *gin.RouterGroup,
+	client clients.IClientRegistry,
+) IPromoRoute {
+	return &PromoRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (p *PromoRoute) Run() {
+	group := p.group.Group("/promo")
+	group.POST("/quote", middlewares.AuthenticateWithoutToken(), p.controller.GetPromo().Quote)
+	group.Use(middlewares.Authenticate())
+	group.Use(middlewares.CheckRole([]string{
+		constants.Admin,
+	}, p.client))
+	group.GET("", p.controller.GetPromo().GetAll)
+	group.GET("/:uuid", p.controller.GetPromo().GetByUUID)
+	group.POST("", p.controller.GetPromo().Create)
+	group.PUT("/:uuid", p.controller.GetPromo().Update)
+	group.DELETE("/:uuid", p.controller.GetPromo().Delete)
+}
@@ -0,0 +1,69 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/controllers"
+	fieldRoute "field-service/routes/field"
+	fieldScheduleRoute "field-service/routes/field_schedule"
+	pricingRuleRoute "field-service/routes/pricing_rule"
+	promoRoute "field-service/routes/promo"
+	timeRoute "field-service/routes/time"
+	userCacheRoute "field-service/routes/user_cache"
+
+	"github.com/gin-gonic/gin"
+)
+
+type Registry struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IRouteRegistry interface {
+	Serve()
+}
+
+func NewRouteRegistry(
+	controller controllers.IControllerRegistry,
+	group *gin.RouterGroup,
+	client clients.IClientRegistry,
+) IRouteRegistry {
+	return &Registry{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (r *Registry) Serve() {
+	r.fieldRoute().Run()
+	r.fieldScheduleRoute().Run()
+	r.pricingRuleRoute().Run()
+	r.promoRoute().Run()
+	r.timeRoute().Run()
+	r.userCacheRoute().Run()
+}
+
+func (r *Registry) fieldRoute() fieldRoute.IFieldRoute {
+	return fieldRoute.NewFieldRoute(r.controller, r.group, r.client)
+}
+
+func (r *Registry) timeRoute() timeRoute.ITimeRoute {
+	return timeRoute.NewTimeRoute(r.controller, r.group, r.client)
+}
+
+func (r *Registry) fieldScheduleRoute() fieldScheduleRoute.IFieldScheduleRoute {
+	return fieldScheduleRoute.NewFieldScheduleRoute(r.controller, r.group, r.client)
+}
+
+func (r *Registry) pricingRuleRoute() pricingRuleRoute.IPricingRuleRoute {
+	return pricingRuleRoute.NewPricingRuleRoute(r.controller, r.group, r.client)
+}
+
+func (r *Registry) promoRoute() promoRoute.IPromoRoute {
+	return promoRoute.NewPromoRoute(r.controller, r.group, r.client)
+}
+
+func (r *Registry) userCacheRoute() userCacheRoute.IUserCacheRoute {
+	return userCacheRoute.NewUserCacheRoute(r.controller, r.group, r.client)
+}
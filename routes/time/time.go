@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TimeRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type ITimeRoute interface {
+	Run()
+}
+
+func NewTimeRoute(
+	controller controllers.IControllerRegistry,
+	group *gin.RouterGroup,
+	client clients.IClientRegistry,
+) ITimeRoute {
+	return &TimeRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (t *TimeRoute) Run() {
+	group := t.group.Group("/time")
+	group.Use(middlewares.Authenticate())
+	group.Use(middlewares.CheckRole([]string{
+		constants.Admin,
+	}, t.client))
+	group.GET("", t.controller.GetTime().GetAll)
+	group.GET("/:uuid", t.controller.GetTime().GetByUUID)
+	group.POST("", t.controller.GetTime().Create)
+	group.PUT("/:uuid", t.controller.GetTime().Update)
+}
@@ -0,0 +1,38 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/controllers"
+	"field-service/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserCacheRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IUserCacheRoute interface {
+	Run()
+}
+
+func NewUserCacheRoute(
+	controller controllers.IControllerRegistry,
+	group *gin.RouterGroup,
+	client clients.IClientRegistry,
+) IUserCacheRoute {
+	return &UserCacheRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+// Run registers the endpoints user-service calls, authenticated with the
+// service API key instead of a user token.
+func (u *UserCacheRoute) Run() {
+	group := u.group.Group("/user-cache")
+	group.POST("/evict", middlewares.AuthenticateWithoutToken(), u.controller.GetUserCache().Evict)
+}
@@ -0,0 +1,82 @@
+package seeders
+
+import (
+	"context"
+	"field-service/domain/models"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+const (
+	openingHour = 8
+	closingHour = 23
+)
+
+var demoFields = []models.Field{
+	{Code: "FLD-001", Name: "Lapangan A", PricePerHour: 150000},
+	{Code: "FLD-002", Name: "Lapangan B", PricePerHour: 200000},
+}
+
+type Seeder struct {
+	db *gorm.DB
+}
+
+type ISeeder interface {
+	Run(context.Context) error
+}
+
+func NewSeeder(db *gorm.DB) ISeeder {
+	return &Seeder{db: db}
+}
+
+// Run inserts the default hourly time slots and the demo fields. Rows that
+// already exist are left alone, so seeding can be repeated safely.
+func (s *Seeder) Run(ctx context.Context) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := seedTimes(tx)
+		if err != nil {
+			return err
+		}
+
+		return seedFields(tx)
+	})
+}
+
+func seedTimes(tx *gorm.DB) error {
+	for hour := openingHour; hour < closingHour; hour++ {
+		slot := models.Time{
+			StartTime: fmt.Sprintf("%02d:00:00", hour),
+			EndTime:   fmt.Sprintf("%02d:00:00", hour+1),
+		}
+
+		err := tx.
+			Where("start_time = ? AND end_time = ?", slot.StartTime, slot.EndTime).
+			Attrs(models.Time{UUID: uuid.New()}).
+			FirstOrCreate(&slot).
+			Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func seedFields(tx *gorm.DB) error {
+	for _, demo := range demoFields {
+		field := demo
+		err := tx.
+			Where("code = ?", field.Code).
+			Attrs(models.Field{UUID: uuid.New(), Images: pq.StringArray{}}).
+			FirstOrCreate(&field).
+			Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
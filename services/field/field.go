@@ -0,0 +1,298 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	"field-service/common/imaging"
+	"field-service/common/logger"
+	"field-service/common/storage"
+	"field-service/common/utils"
+	errConstant "field-service/constants/error"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	fieldScheduleService "field-service/services/field_schedule"
+	"fmt"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxUploadImages   = 5
+	maxUploadFileSize = 5 * 1024 * 1024
+	maxImageDimension = 4096
+	minImageDimension = 64
+	thumbnailWidth    = 320
+)
+
+type FieldService struct {
+	repository repositories.IRepositoryRegistry
+	storage    storage.IStorageClient
+}
+
+type IFieldService interface {
+	GetAllWithPagination(context.Context, *dto.FieldRequestParam) (*utils.PaginationResult, error)
+	GetByUUID(context.Context, string) (*dto.FieldResponse, error)
+	Create(context.Context, *dto.FieldRequest) (*dto.FieldResponse, error)
+	Update(context.Context, string, *dto.UpdateFieldRequest) (*dto.FieldResponse, error)
+	Delete(context.Context, string) error
+}
+
+func NewFieldService(repository repositories.IRepositoryRegistry, storage storage.IStorageClient) IFieldService {
+	return &FieldService{
+		repository: repository,
+		storage:    storage,
+	}
+}
+
+func toFieldResponse(field *models.Field) dto.FieldResponse {
+	return dto.FieldResponse{
+		UUID:         field.UUID,
+		Code:         field.Code,
+		Name:         field.Name,
+		PricePerHour: field.PricePerHour,
+		Images:       field.Images,
+		Thumbnails:   field.Thumbnails,
+		CreatedAt:    field.CreatedAt,
+		UpdatedAt:    field.UpdatedAt,
+	}
+}
+
+func (f *FieldService) GetAllWithPagination(
+	ctx context.Context,
+	param *dto.FieldRequestParam,
+) (*utils.PaginationResult, error) {
+	fields, total, err := f.repository.GetField().FindAllWithPagination(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldResults := make([]dto.FieldResponse, 0, len(fields))
+	for _, field := range fields {
+		fieldResults = append(fieldResults, toFieldResponse(&field))
+	}
+
+	pagination := utils.PaginationParam{
+		Count: total,
+		Page:  param.Page,
+		Limit: param.Limit,
+		Data:  fieldResults,
+	}
+
+	response := utils.GeneratePagination(pagination)
+	return &response, nil
+}
+
+func (f *FieldService) GetByUUID(ctx context.Context, uuid string) (*dto.FieldResponse, error) {
+	field, err := f.repository.GetField().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toFieldResponse(field)
+	return &response, nil
+}
+
+func (f *FieldService) validateUpload(images []multipart.FileHeader) error {
+	if len(images) == 0 || len(images) > maxUploadImages {
+		return errConstant.ErrInvalidUploadFile
+	}
+
+	for _, image := range images {
+		if image.Size > maxUploadFileSize {
+			return errConstant.ErrSizeTooBig
+		}
+	}
+
+	return nil
+}
+
+func toImagingError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, imaging.ErrFileTooLarge):
+		return errWrap.WrapErr(ctx, errConstant.ErrSizeTooBig)
+	case errors.Is(err, imaging.ErrDimensionTooLarge), errors.Is(err, imaging.ErrDimensionTooSmall):
+		return errWrap.WrapErr(ctx, errConstant.ErrInvalidImageDimension)
+	case errors.Is(err, imaging.ErrNotAnImage):
+		return errWrap.WrapErr(ctx, errConstant.ErrInvalidUploadFile)
+	default:
+		return err
+	}
+}
+
+func (f *FieldService) processAndUploadImage(
+	ctx context.Context,
+	image multipart.FileHeader,
+) (string, string, error) {
+	file, err := image.Open()
+	if err != nil {
+		return "", "", err
+	}
+	defer file.Close()
+
+	buffer := new(bytes.Buffer)
+	_, err = buffer.ReadFrom(file)
+	if err != nil {
+		return "", "", err
+	}
+
+	result, err := imaging.Process(buffer.Bytes(), imaging.Options{
+		MaxFileSize:    maxUploadFileSize,
+		MaxWidth:       maxImageDimension,
+		MaxHeight:      maxImageDimension,
+		MinWidth:       minImageDimension,
+		MinHeight:      minImageDimension,
+		ThumbnailWidth: thumbnailWidth,
+	})
+	if err != nil {
+		return "", "", toImagingError(ctx, err)
+	}
+
+	filename := fmt.Sprintf("images/%s-%s",
+		time.Now().Format("20060102150405"),
+		uuid.New().String(),
+	)
+	imageURL, err := f.storage.UpdloadFile(ctx, filename+result.Extension, result.Original)
+	if err != nil {
+		return "", "", err
+	}
+
+	thumbnailURL, err := f.storage.UpdloadFile(ctx, filename+"_thumb"+result.Extension, result.Thumbnail)
+	if err != nil {
+		f.deleteImages(ctx, []string{imageURL})
+		return "", "", err
+	}
+
+	return imageURL, thumbnailURL, nil
+}
+
+func (f *FieldService) uploadImage(
+	ctx context.Context,
+	images []multipart.FileHeader,
+) ([]string, []string, error) {
+	err := f.validateUpload(images)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	imageURLs := make([]string, 0, len(images))
+	thumbnailURLs := make([]string, 0, len(images))
+	for _, image := range images {
+		imageURL, thumbnailURL, err := f.processAndUploadImage(ctx, image)
+		if err != nil {
+			f.deleteImages(ctx, append(imageURLs, thumbnailURLs...))
+			return nil, nil, err
+		}
+		imageURLs = append(imageURLs, imageURL)
+		thumbnailURLs = append(thumbnailURLs, thumbnailURL)
+	}
+
+	return imageURLs, thumbnailURLs, nil
+}
+
+// deleteImages removes previously uploaded images. It is best effort: the
+// field change has already been committed, so failures are only logged.
+func (f *FieldService) deleteImages(ctx context.Context, imageURLs []string) {
+	prefix := f.storage.URL("")
+	for _, imageURL := range imageURLs {
+		fileName, ok := strings.CutPrefix(imageURL, prefix)
+		if !ok {
+			logger.FromContext(ctx).Warnf("skip deleting image outside of storage: %s", imageURL)
+			continue
+		}
+
+		err := f.storage.Delete(ctx, fileName)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("failed to delete image %s: %v", fileName, err)
+		}
+	}
+}
+
+func (f *FieldService) Create(ctx context.Context, request *dto.FieldRequest) (*dto.FieldResponse, error) {
+	imageURLs, thumbnailURLs, err := f.uploadImage(ctx, request.Images)
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := f.repository.GetField().Create(ctx, &models.Field{
+		UUID:         uuid.New(),
+		Code:         request.Code,
+		Name:         request.Name,
+		PricePerHour: request.PricePerHour,
+		Images:       imageURLs,
+		Thumbnails:   thumbnailURLs,
+	})
+	if err != nil {
+		f.deleteImages(ctx, append(imageURLs, thumbnailURLs...))
+		return nil, err
+	}
+
+	response := toFieldResponse(field)
+	return &response, nil
+}
+
+func (f *FieldService) Update(
+	ctx context.Context,
+	uuidParam string,
+	request *dto.UpdateFieldRequest,
+) (*dto.FieldResponse, error) {
+	field, err := f.repository.GetField().FindByUUID(ctx, uuidParam)
+	if err != nil {
+		return nil, err
+	}
+
+	imageURLs := []string(field.Images)
+	thumbnailURLs := []string(field.Thumbnails)
+	if len(request.Images) > 0 {
+		imageURLs, thumbnailURLs, err = f.uploadImage(ctx, request.Images)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fieldResult, err := f.repository.GetField().Update(ctx, uuidParam, &models.Field{
+		Code:         request.Code,
+		Name:         request.Name,
+		PricePerHour: request.PricePerHour,
+		Images:       imageURLs,
+		Thumbnails:   thumbnailURLs,
+	})
+	if err != nil {
+		if len(request.Images) > 0 {
+			f.deleteImages(ctx, append(imageURLs, thumbnailURLs...))
+		}
+		return nil, err
+	}
+
+	if len(request.Images) > 0 {
+		f.deleteImages(ctx, append(field.Images, field.Thumbnails...))
+	}
+
+	response := toFieldResponse(fieldResult)
+	return &response, nil
+}
+
+func (f *FieldService) Delete(ctx context.Context, uuid string) error {
+	field, err := f.repository.GetField().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	// The field and its schedules are soft deleted so past bookings and
+	// pricing rules keep pointing at a row. Its images are kept in storage
+	// on purpose: the soft deleted row still references them.
+	return f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		err := fieldScheduleService.NewFieldScheduleService(f.repository).DeleteByFieldID(ctx, tx, field.ID)
+		if err != nil {
+			return err
+		}
+
+		return f.repository.GetField().Delete(ctx, tx, uuid)
+	})
+}
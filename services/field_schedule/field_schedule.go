@@ -0,0 +1,572 @@
+package services
+
+import (
+	"context"
+	errWrap "field-service/common/error"
+	"field-service/common/utils"
+	"field-service/config"
+	"field-service/constants"
+	errField "field-service/constants/error/field"
+	errFieldSchedule "field-service/constants/error/field_schedule"
+	errTime "field-service/constants/error/time"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	outboxService "field-service/services/outbox"
+	pricingRuleService "field-service/services/pricing_rule"
+	promoService "field-service/services/promo"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	dateFormat     = "2006-01-02"
+	oneMonthInDays = 30
+
+	defaultHoldMinutes = 15
+)
+
+type FieldScheduleService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IFieldScheduleService interface {
+	GetAllByFieldIDAndDate(context.Context, string, string) ([]dto.FieldScheduleForBookingResponse, error)
+	GetByUUID(context.Context, string) (*dto.FieldScheduleResponse, error)
+	UpdateStatus(context.Context, *dto.UpdateStatusFieldScheduleRequest) error
+	Hold(context.Context, *dto.HoldFieldScheduleRequest) (*dto.HoldFieldScheduleResponse, error)
+	Release(context.Context, *dto.ReleaseFieldScheduleRequest) (*dto.ReleaseFieldScheduleResponse, error)
+	ReleaseExpiredHolds(context.Context) (int64, error)
+	HandleOrderPaid(context.Context, string, *dto.OrderEvent) error
+	HandleOrderReleased(context.Context, string, *dto.OrderEvent) error
+	DeleteByFieldID(context.Context, *gorm.DB, uint) error
+	GenerateScheduleForOneMonth(
+		context.Context,
+		*dto.GenerateFieldScheduleForOneMonthRequest,
+	) (*dto.GenerateFieldScheduleForOneMonthResponse, error)
+}
+
+func NewFieldScheduleService(repository repositories.IRepositoryRegistry) IFieldScheduleService {
+	return &FieldScheduleService{repository: repository}
+}
+
+// effectiveStatus reports a hold that has expired but not been swept yet as
+// available, so callers never see a slot blocked by an abandoned payment.
+func effectiveStatus(fieldSchedule *models.FieldSchedule, now time.Time) constants.FieldScheduleStatus {
+	if fieldSchedule.Status == constants.Held &&
+		fieldSchedule.HeldUntil != nil &&
+		!fieldSchedule.HeldUntil.After(now) {
+		return constants.Available
+	}
+
+	return fieldSchedule.Status
+}
+
+func (f *FieldScheduleService) GetAllByFieldIDAndDate(
+	ctx context.Context,
+	uuid, date string,
+) ([]dto.FieldScheduleForBookingResponse, error) {
+	_, err := time.Parse(dateFormat, date)
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errFieldSchedule.ErrInvalidDateFormat)
+	}
+
+	field, err := f.repository.GetField().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldSchedules, err := f.repository.GetFieldSchedule().FindAllByFieldIDAndDate(ctx, field.ID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	pricingRules, err := f.repository.GetPricingRule().FindAllByFieldID(ctx, field.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	fieldScheduleResults := make([]dto.FieldScheduleForBookingResponse, 0, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		pricePerHour := float64(pricingRuleService.ResolvePrice(
+			pricingRules,
+			fieldSchedule.Field.PricePerHour,
+			fieldSchedule.Date,
+			&fieldSchedule.Time,
+		))
+		fieldScheduleResults = append(fieldScheduleResults, dto.FieldScheduleForBookingResponse{
+			UUID:         fieldSchedule.UUID,
+			PricePerHour: utils.GenerateRupiahFormat(&pricePerHour),
+			Date:         fieldSchedule.Date.Format(dateFormat),
+			Status:       effectiveStatus(&fieldSchedule, now).GetStatusString(),
+			Time:         fmt.Sprintf("%s - %s", fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime),
+		})
+	}
+
+	return fieldScheduleResults, nil
+}
+
+func toFieldScheduleResponse(
+	fieldSchedule *models.FieldSchedule,
+	pricingRules []models.PricingRule,
+	now time.Time,
+) dto.FieldScheduleResponse {
+	return dto.FieldScheduleResponse{
+		UUID:      fieldSchedule.UUID,
+		FieldName: fieldSchedule.Field.Name,
+		PricePerHour: pricingRuleService.ResolvePrice(
+			pricingRules,
+			fieldSchedule.Field.PricePerHour,
+			fieldSchedule.Date,
+			&fieldSchedule.Time,
+		),
+		Date:      fieldSchedule.Date.Format(dateFormat),
+		Status:    effectiveStatus(fieldSchedule, now).GetStatusString(),
+		Time:      fmt.Sprintf("%s - %s", fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime),
+		CreatedAt: fieldSchedule.CreatedAt,
+		UpdatedAt: fieldSchedule.UpdatedAt,
+	}
+}
+
+func (f *FieldScheduleService) GetByUUID(ctx context.Context, uuid string) (*dto.FieldScheduleResponse, error) {
+	fieldSchedule, err := f.repository.GetFieldSchedule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	pricingRules, err := f.repository.GetPricingRule().FindAllByFieldID(ctx, fieldSchedule.FieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toFieldScheduleResponse(fieldSchedule, pricingRules, time.Now())
+	return &response, nil
+}
+
+// recordEvents reads the schedules back inside tx and writes one outbox event
+// per schedule describing its state after the change.
+func (f *FieldScheduleService) recordEvents(
+	ctx context.Context,
+	tx *gorm.DB,
+	eventType constants.FieldScheduleEventType,
+	uuids []string,
+) error {
+	if len(uuids) == 0 {
+		return nil
+	}
+
+	fieldSchedules, err := f.repository.GetFieldSchedule().FindByUUIDs(ctx, tx, uuids)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	pricingRulesByField := make(map[uint][]models.PricingRule)
+	responses := make([]dto.FieldScheduleResponse, 0, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		pricingRules, ok := pricingRulesByField[fieldSchedule.FieldID]
+		if !ok {
+			pricingRules, err = f.repository.GetPricingRule().FindAllByFieldID(ctx, fieldSchedule.FieldID)
+			if err != nil {
+				return err
+			}
+			pricingRulesByField[fieldSchedule.FieldID] = pricingRules
+		}
+
+		responses = append(responses, toFieldScheduleResponse(&fieldSchedule, pricingRules, now))
+	}
+
+	return outboxService.NewOutboxService(f.repository).RecordFieldScheduleEvents(ctx, tx, eventType, responses)
+}
+
+// DeleteByFieldID soft deletes every schedule of a field in tx and records
+// their deleted events. It refuses while a schedule from today on is booked or
+// held, so a field is never removed from under a customer's order.
+func (f *FieldScheduleService) DeleteByFieldID(ctx context.Context, tx *gorm.DB, fieldID uint) error {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	upcoming, err := f.repository.GetFieldSchedule().FindFromDateByFieldIDForUpdate(ctx, tx, fieldID, today)
+	if err != nil {
+		return err
+	}
+
+	for i := range upcoming {
+		if effectiveStatus(&upcoming[i], now) != constants.Available {
+			return errWrap.WrapErr(ctx, errField.ErrFieldHasUpcomingSchedules)
+		}
+	}
+
+	uuids, err := f.repository.GetFieldSchedule().FindUUIDsByFieldID(ctx, tx, fieldID)
+	if err != nil {
+		return err
+	}
+
+	err = f.recordEvents(ctx, tx, constants.FieldScheduleDeleted, uuids)
+	if err != nil {
+		return err
+	}
+
+	return f.repository.GetFieldSchedule().SoftDeleteByFieldID(ctx, tx, fieldID, now)
+}
+
+func (f *FieldScheduleService) GenerateScheduleForOneMonth(
+	ctx context.Context,
+	request *dto.GenerateFieldScheduleForOneMonthRequest,
+) (*dto.GenerateFieldScheduleForOneMonthResponse, error) {
+	field, err := f.repository.GetField().FindByUUID(ctx, request.FieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	times, err := f.repository.GetTime().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(times) == 0 {
+		return nil, errWrap.WrapErr(ctx, errTime.ErrTimeNotFound)
+	}
+
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	endDate := startDate.AddDate(0, 0, oneMonthInDays-1)
+	response := &dto.GenerateFieldScheduleForOneMonthResponse{
+		FieldName:    field.Name,
+		StartDate:    startDate.Format(dateFormat),
+		EndDate:      endDate.Format(dateFormat),
+		CreatedDates: make([]string, 0, oneMonthInDays),
+		SkippedDates: make([]string, 0),
+	}
+
+	err = f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		existingDates, txErr := f.repository.GetFieldSchedule().
+			FindDatesByFieldIDAndDateRange(ctx, tx, field.ID, startDate, endDate)
+		if txErr != nil {
+			return txErr
+		}
+
+		scheduledDates := make(map[string]struct{}, len(existingDates))
+		for _, date := range existingDates {
+			scheduledDates[date.Format(dateFormat)] = struct{}{}
+		}
+
+		fieldSchedules := make([]models.FieldSchedule, 0, oneMonthInDays*len(times))
+		for i := 0; i < oneMonthInDays; i++ {
+			date := startDate.AddDate(0, 0, i)
+			dateString := date.Format(dateFormat)
+			if _, ok := scheduledDates[dateString]; ok {
+				response.SkippedDates = append(response.SkippedDates, dateString)
+				continue
+			}
+
+			for _, item := range times {
+				fieldSchedules = append(fieldSchedules, models.FieldSchedule{
+					UUID:    uuid.New(),
+					FieldID: field.ID,
+					TimeID:  item.ID,
+					Date:    date,
+					Status:  constants.Available,
+				})
+			}
+			response.CreatedDates = append(response.CreatedDates, dateString)
+		}
+
+		if len(fieldSchedules) == 0 {
+			return errWrap.WrapErr(ctx, errFieldSchedule.ErrFieldScheduleIsExist)
+		}
+
+		txErr = f.repository.GetFieldSchedule().Create(ctx, tx, fieldSchedules)
+		if txErr != nil {
+			return txErr
+		}
+
+		uuids := make([]string, 0, len(fieldSchedules))
+		for _, fieldSchedule := range fieldSchedules {
+			uuids = append(uuids, fieldSchedule.UUID.String())
+		}
+
+		txErr = f.recordEvents(ctx, tx, constants.FieldScheduleCreated, uuids)
+		if txErr != nil {
+			return txErr
+		}
+
+		response.TotalCreated = len(fieldSchedules)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// normalizeUUIDs parses, lower-cases and de-duplicates the requested ids so
+// they can be compared against the values read back from the database.
+func normalizeUUIDs(ctx context.Context, ids []string) ([]string, error) {
+	uuids := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return nil, errWrap.WrapErr(ctx, fmt.Errorf("%w: %s", errFieldSchedule.ErrFieldScheduleNotFound, id))
+		}
+		uuids = append(uuids, parsed.String())
+	}
+	slices.Sort(uuids)
+
+	return slices.Compact(uuids), nil
+}
+
+func isHeldByAnotherOrder(fieldSchedule *models.FieldSchedule, orderID *string, now time.Time) bool {
+	if fieldSchedule.Status != constants.Held || fieldSchedule.HeldUntil == nil {
+		return false
+	}
+
+	if !fieldSchedule.HeldUntil.After(now) {
+		return false
+	}
+
+	return orderID == nil || fieldSchedule.OrderID == nil || *fieldSchedule.OrderID != *orderID
+}
+
+// lockForOrder locks the schedules and checks that every one of them exists
+// and can still be taken by orderID, naming the offending ids otherwise.
+func (f *FieldScheduleService) lockForOrder(
+	ctx context.Context,
+	tx *gorm.DB,
+	uuids []string,
+	orderID *string,
+) error {
+	fieldSchedules, err := f.repository.GetFieldSchedule().FindByUUIDsForUpdate(ctx, tx, uuids)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	found := make(map[string]struct{}, len(fieldSchedules))
+	booked := make([]string, 0)
+	held := make([]string, 0)
+	for _, fieldSchedule := range fieldSchedules {
+		found[fieldSchedule.UUID.String()] = struct{}{}
+		if fieldSchedule.Status == constants.Booked {
+			booked = append(booked, fieldSchedule.UUID.String())
+		} else if isHeldByAnotherOrder(&fieldSchedule, orderID, now) {
+			held = append(held, fieldSchedule.UUID.String())
+		}
+	}
+
+	missing := make([]string, 0)
+	for _, id := range uuids {
+		if _, ok := found[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		return errWrap.WrapErr(ctx, fmt.Errorf("%w: %s",
+			errFieldSchedule.ErrFieldScheduleNotFound, strings.Join(missing, ", ")))
+	}
+
+	if len(booked) > 0 {
+		return errWrap.WrapErr(ctx, fmt.Errorf("%w: %s",
+			errFieldSchedule.ErrFieldScheduleIsBooked, strings.Join(booked, ", ")))
+	}
+
+	if len(held) > 0 {
+		return errWrap.WrapErr(ctx, fmt.Errorf("%w: %s",
+			errFieldSchedule.ErrFieldScheduleIsHeld, strings.Join(held, ", ")))
+	}
+
+	return nil
+}
+
+func (f *FieldScheduleService) UpdateStatus(
+	ctx context.Context,
+	request *dto.UpdateStatusFieldScheduleRequest,
+) error {
+	uuids, err := normalizeUUIDs(ctx, request.FieldScheduleIDs)
+	if err != nil {
+		return err
+	}
+
+	return f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		return f.book(ctx, tx, uuids, request)
+	})
+}
+
+func (f *FieldScheduleService) book(
+	ctx context.Context,
+	tx *gorm.DB,
+	uuids []string,
+	request *dto.UpdateStatusFieldScheduleRequest,
+) error {
+	err := f.lockForOrder(ctx, tx, uuids, request.OrderID)
+	if err != nil {
+		return err
+	}
+
+	if request.PromoCode != nil && *request.PromoCode != "" {
+		err = promoService.NewPromoService(f.repository).Redeem(ctx, tx, &dto.PromoRedeemParam{
+			PromoCode:        *request.PromoCode,
+			UserID:           request.UserID,
+			OrderID:          request.OrderID,
+			FieldScheduleIDs: uuids,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = f.repository.GetFieldSchedule().UpdateStatus(ctx, tx, constants.Booked, request.OrderID, uuids)
+	if err != nil {
+		return err
+	}
+
+	return f.recordEvents(ctx, tx, constants.FieldScheduleBooked, uuids)
+}
+
+// markProcessed records the event inside tx and reports false when it was
+// handled before, so the caller can skip it.
+func (f *FieldScheduleService) markProcessed(
+	ctx context.Context,
+	tx *gorm.DB,
+	subject string,
+	eventID string,
+) (bool, error) {
+	return f.repository.GetProcessedEvent().Create(ctx, tx, &models.ProcessedEvent{
+		EventID:     eventID,
+		Subject:     subject,
+		ProcessedAt: time.Now(),
+	})
+}
+
+// HandleOrderPaid books the schedules of a paid order. Redelivered events
+// are ignored.
+func (f *FieldScheduleService) HandleOrderPaid(ctx context.Context, subject string, event *dto.OrderEvent) error {
+	uuids, err := normalizeUUIDs(ctx, event.FieldScheduleIDs)
+	if err != nil {
+		return err
+	}
+
+	return f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		isNew, err := f.markProcessed(ctx, tx, subject, event.EventID)
+		if err != nil || !isNew {
+			return err
+		}
+
+		return f.book(ctx, tx, uuids, &dto.UpdateStatusFieldScheduleRequest{
+			OrderID:          &event.OrderID,
+			UserID:           event.UserID,
+			PromoCode:        event.PromoCode,
+			FieldScheduleIDs: uuids,
+		})
+	})
+}
+
+// HandleOrderReleased returns the schedules of a cancelled or expired order to
+// available. Schedules already taken by another order are left untouched.
+func (f *FieldScheduleService) HandleOrderReleased(ctx context.Context, subject string, event *dto.OrderEvent) error {
+	uuids, err := normalizeUUIDs(ctx, event.FieldScheduleIDs)
+	if err != nil {
+		return err
+	}
+
+	return f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		isNew, err := f.markProcessed(ctx, tx, subject, event.EventID)
+		if err != nil || !isNew {
+			return err
+		}
+
+		released, err := f.repository.GetFieldSchedule().ReleaseByOrderIDAndUUIDs(ctx, tx, event.OrderID, uuids)
+		if err != nil {
+			return err
+		}
+
+		return f.recordEvents(ctx, tx, constants.FieldScheduleReleased, released)
+	})
+}
+
+func holdDuration() time.Duration {
+	minutes := config.Config.FieldScheduleHoldMinutes
+	if minutes <= 0 {
+		minutes = defaultHoldMinutes
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
+
+func (f *FieldScheduleService) Hold(
+	ctx context.Context,
+	request *dto.HoldFieldScheduleRequest,
+) (*dto.HoldFieldScheduleResponse, error) {
+	uuids, err := normalizeUUIDs(ctx, request.FieldScheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	heldUntil := time.Now().Add(holdDuration())
+	err = f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		err := f.lockForOrder(ctx, tx, uuids, &request.OrderID)
+		if err != nil {
+			return err
+		}
+
+		return f.repository.GetFieldSchedule().Hold(ctx, tx, request.OrderID, heldUntil, uuids)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.HoldFieldScheduleResponse{
+		OrderID:          request.OrderID,
+		FieldScheduleIDs: uuids,
+		HeldUntil:        heldUntil,
+	}, nil
+}
+
+func (f *FieldScheduleService) Release(
+	ctx context.Context,
+	request *dto.ReleaseFieldScheduleRequest,
+) (*dto.ReleaseFieldScheduleResponse, error) {
+	var released []string
+	err := f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		var err error
+		released, err = f.repository.GetFieldSchedule().ReleaseByOrderID(ctx, tx, request.OrderID)
+		if err != nil {
+			return err
+		}
+
+		return f.recordEvents(ctx, tx, constants.FieldScheduleReleased, released)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.ReleaseFieldScheduleResponse{
+		OrderID:       request.OrderID,
+		TotalReleased: int64(len(released)),
+	}, nil
+}
+
+func (f *FieldScheduleService) ReleaseExpiredHolds(ctx context.Context) (int64, error) {
+	var released []string
+	err := f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		var err error
+		released, err = f.repository.GetFieldSchedule().ReleaseExpiredHolds(ctx, tx, time.Now())
+		if err != nil {
+			return err
+		}
+
+		return f.recordEvents(ctx, tx, constants.FieldScheduleReleased, released)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(released)), nil
+}
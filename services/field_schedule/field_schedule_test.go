@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"field-service/constants"
+	errFieldSchedule "field-service/constants/error/field_schedule"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/migrations"
+	"field-service/repositories"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const concurrentCallers = 20
+
+// openTestDB connects to the database in TEST_DATABASE_URL and applies the
+// migrations. Tests that need Postgres are skipped when it is not set.
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+
+	_, err = migrator.Up(context.Background())
+	if err != nil {
+		t.Fatalf("migrate database: %v", err)
+	}
+
+	return db
+}
+
+// createTestField inserts a field and a time slot that are removed again when
+// the test ends, together with their schedules.
+func createTestField(t *testing.T, db *gorm.DB) *models.Field {
+	t.Helper()
+
+	slot := &models.Time{UUID: uuid.New(), StartTime: "06:00:00", EndTime: "07:00:00"}
+	err := db.Create(slot).Error
+	if err != nil {
+		t.Fatalf("create time: %v", err)
+	}
+
+	field := &models.Field{
+		UUID:         uuid.New(),
+		Code:         fmt.Sprintf("TST-%d", slot.ID%100000),
+		Name:         "Concurrency Test Field",
+		PricePerHour: 100000,
+		Images:       pq.StringArray{},
+	}
+	err = db.Create(field).Error
+	if err != nil {
+		t.Fatalf("create field: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM field_schedules WHERE field_id = ?", field.ID)
+		db.Exec("DELETE FROM fields WHERE id = ?", field.ID)
+		db.Exec("DELETE FROM times WHERE id = ?", slot.ID)
+	})
+
+	return field
+}
+
+// runConcurrently starts every call at the same time and returns their errors.
+func runConcurrently(n int, call func(int) error) []error {
+	var (
+		start sync.WaitGroup
+		done  sync.WaitGroup
+	)
+	errs := make([]error, n)
+
+	start.Add(1)
+	for i := 0; i < n; i++ {
+		done.Add(1)
+		go func(i int) {
+			defer done.Done()
+			start.Wait()
+			errs[i] = call(i)
+		}(i)
+	}
+	start.Done()
+	done.Wait()
+
+	return errs
+}
+
+func TestGenerateScheduleForOneMonthConcurrently(t *testing.T) {
+	db := openTestDB(t)
+	field := createTestField(t, db)
+	service := NewFieldScheduleService(repositories.NewRepositoryRegistry(db))
+
+	errs := runConcurrently(concurrentCallers, func(int) error {
+		_, err := service.GenerateScheduleForOneMonth(context.Background(), &dto.GenerateFieldScheduleForOneMonthRequest{
+			FieldID: field.UUID.String(),
+		})
+		return err
+	})
+
+	succeeded := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, errFieldSchedule.ErrFieldScheduleIsExist):
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("expected exactly one generation to succeed, got %d", succeeded)
+	}
+
+	var duplicates int64
+	err := db.Raw(`
+		SELECT COUNT(*) FROM (
+			SELECT 1 FROM field_schedules
+			WHERE field_id = ? AND deleted_at IS NULL
+			GROUP BY date, time_id
+			HAVING COUNT(*) > 1
+		) AS duplicated`, field.ID).Scan(&duplicates).Error
+	if err != nil {
+		t.Fatalf("count duplicates: %v", err)
+	}
+	if duplicates != 0 {
+		t.Errorf("expected no duplicate slots, got %d", duplicates)
+	}
+
+	existing := firstSchedule(t, db, field)
+	err = db.Create(&models.FieldSchedule{
+		UUID:    uuid.New(),
+		FieldID: field.ID,
+		TimeID:  existing.TimeID,
+		Date:    existing.Date,
+		Status:  constants.Available,
+	}).Error
+	if err == nil {
+		t.Error("expected the unique index to reject a duplicate slot")
+	}
+}
+
+func TestUpdateStatusConcurrently(t *testing.T) {
+	db := openTestDB(t)
+	field := createTestField(t, db)
+	service := NewFieldScheduleService(repositories.NewRepositoryRegistry(db))
+
+	_, err := service.GenerateScheduleForOneMonth(context.Background(), &dto.GenerateFieldScheduleForOneMonthRequest{
+		FieldID: field.UUID.String(),
+	})
+	if err != nil {
+		t.Fatalf("generate schedules: %v", err)
+	}
+	fieldSchedule := firstSchedule(t, db, field)
+
+	errs := runConcurrently(concurrentCallers, func(i int) error {
+		orderID := fmt.Sprintf("order-%d", i)
+		return service.UpdateStatus(context.Background(), &dto.UpdateStatusFieldScheduleRequest{
+			OrderID:          &orderID,
+			FieldScheduleIDs: []string{fieldSchedule.UUID.String()},
+		})
+	})
+
+	succeeded := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, errFieldSchedule.ErrFieldScheduleIsBooked):
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("expected exactly one booking to succeed, got %d", succeeded)
+	}
+
+	var booked models.FieldSchedule
+	err = db.Where("id = ?", fieldSchedule.ID).First(&booked).Error
+	if err != nil {
+		t.Fatalf("reload schedule: %v", err)
+	}
+	if booked.OrderID == nil {
+		t.Error("expected the schedule to keep the winning order id")
+	}
+}
+
+func firstSchedule(t *testing.T, db *gorm.DB, field *models.Field) *models.FieldSchedule {
+	t.Helper()
+
+	var fieldSchedule models.FieldSchedule
+	err := db.Where("field_id = ? AND deleted_at IS NULL", field.ID).Order("id asc").First(&fieldSchedule).Error
+	if err != nil {
+		t.Fatalf("find schedule: %v", err)
+	}
+
+	return &fieldSchedule
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"field-service/config"
+	"field-service/constants"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	minRetryDelay = time.Second
+	maxRetryDelay = 5 * time.Minute
+)
+
+// Publisher is the part of *nats.Conn the relay needs.
+type Publisher interface {
+	Publish(string, []byte) error
+	Flush() error
+}
+
+type OutboxService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IOutboxService interface {
+	RecordFieldScheduleEvents(
+		context.Context,
+		*gorm.DB,
+		constants.FieldScheduleEventType,
+		[]dto.FieldScheduleResponse,
+	) error
+	Relay(context.Context, Publisher, int) (int, error)
+	DeleteSentBefore(context.Context, time.Time) (int64, error)
+}
+
+func NewOutboxService(repository repositories.IRepositoryRegistry) IOutboxService {
+	return &OutboxService{repository: repository}
+}
+
+// RecordFieldScheduleEvents writes one outbox row per schedule inside tx, so
+// the events exist if and only if the schedule change commits. Without a NATS
+// url nothing would ever relay them, so no rows are written.
+func (o *OutboxService) RecordFieldScheduleEvents(
+	ctx context.Context,
+	tx *gorm.DB,
+	eventType constants.FieldScheduleEventType,
+	fieldSchedules []dto.FieldScheduleResponse,
+) error {
+	if config.Config.NATS.URL == "" {
+		return nil
+	}
+
+	now := time.Now()
+	events := make([]models.OutboxEvent, 0, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		eventID := uuid.New()
+		payload, err := json.Marshal(dto.FieldScheduleEvent{
+			Version:    constants.FieldScheduleEventVersion,
+			EventID:    eventID,
+			Type:       eventType,
+			OccurredAt: now,
+			Data:       fieldSchedule,
+		})
+		if err != nil {
+			return err
+		}
+
+		events = append(events, models.OutboxEvent{
+			UUID:          eventID,
+			Subject:       string(eventType),
+			Payload:       string(payload),
+			NextAttemptAt: now,
+		})
+	}
+
+	return o.repository.GetOutbox().Create(ctx, tx, events)
+}
+
+func retryDelay(attempts int) time.Duration {
+	delay := minRetryDelay << min(attempts, 20)
+	return min(delay, maxRetryDelay)
+}
+
+// Relay publishes up to batchSize due events and returns how many were sent.
+// Events that fail to publish are retried later with exponential backoff.
+// Delivery is at least once; consumers deduplicate on the event id.
+func (o *OutboxService) Relay(ctx context.Context, publisher Publisher, batchSize int) (int, error) {
+	sent := 0
+	err := o.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		events, err := o.repository.GetOutbox().FindPendingForUpdate(ctx, tx, now, batchSize)
+		if err != nil {
+			return err
+		}
+
+		sentIDs := make([]uint, 0, len(events))
+		for _, event := range events {
+			err = publisher.Publish(event.Subject, []byte(event.Payload))
+			if err != nil {
+				err = o.repository.GetOutbox().MarkFailed(ctx, tx, event.ID, err.Error(), now.Add(retryDelay(event.Attempts)))
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			sentIDs = append(sentIDs, event.ID)
+		}
+
+		if len(sentIDs) == 0 {
+			return nil
+		}
+
+		// Only mark rows sent once the server has acknowledged them.
+		err = publisher.Flush()
+		if err != nil {
+			return err
+		}
+
+		err = o.repository.GetOutbox().MarkSent(ctx, tx, sentIDs, time.Now())
+		if err != nil {
+			return err
+		}
+
+		sent = len(sentIDs)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return sent, nil
+}
+
+// DeleteSentBefore removes events published before sentBefore, so the table
+// only keeps the pending rows and a short history of sent ones.
+func (o *OutboxService) DeleteSentBefore(ctx context.Context, sentBefore time.Time) (int64, error) {
+	return o.repository.GetOutbox().DeleteSentBefore(ctx, sentBefore)
+}
@@ -0,0 +1,313 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"field-service/config"
+	"field-service/constants"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	fieldRepo "field-service/repositories/field"
+	fieldScheduleRepo "field-service/repositories/field_schedule"
+	outboxRepo "field-service/repositories/outbox"
+	pricingRuleRepo "field-service/repositories/pricing_rule"
+	processedEventRepo "field-service/repositories/processed_event"
+	promoRepo "field-service/repositories/promo"
+	timeRepo "field-service/repositories/time"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+var errNoDatabase = errors.New("no database in this test")
+
+// fakeConnPool lets gorm run Transaction without a database. The relay only
+// reaches the database through the outbox repository, which is faked too.
+type fakeConnPool struct{}
+
+func (p *fakeConnPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, errNoDatabase
+}
+
+func (p *fakeConnPool) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	return nil, errNoDatabase
+}
+
+func (p *fakeConnPool) QueryContext(context.Context, string, ...any) (*sql.Rows, error) {
+	return nil, errNoDatabase
+}
+
+func (p *fakeConnPool) QueryRowContext(context.Context, string, ...any) *sql.Row {
+	return nil
+}
+
+func (p *fakeConnPool) BeginTx(context.Context, *sql.TxOptions) (gorm.ConnPool, error) {
+	return &fakeTx{}, nil
+}
+
+type fakeTx struct {
+	fakeConnPool
+}
+
+func (t *fakeTx) Commit() error   { return nil }
+func (t *fakeTx) Rollback() error { return nil }
+
+type fakeOutboxRepository struct {
+	events []models.OutboxEvent
+}
+
+func (f *fakeOutboxRepository) Create(_ context.Context, _ *gorm.DB, events []models.OutboxEvent) error {
+	for _, event := range events {
+		event.ID = uint(len(f.events) + 1)
+		f.events = append(f.events, event)
+	}
+	return nil
+}
+
+func (f *fakeOutboxRepository) FindPendingForUpdate(
+	_ context.Context,
+	_ *gorm.DB,
+	now time.Time,
+	limit int,
+) ([]models.OutboxEvent, error) {
+	pending := make([]models.OutboxEvent, 0)
+	for _, event := range f.events {
+		if len(pending) == limit {
+			break
+		}
+		if event.SentAt == nil && !event.NextAttemptAt.After(now) {
+			pending = append(pending, event)
+		}
+	}
+	return pending, nil
+}
+
+func (f *fakeOutboxRepository) MarkSent(_ context.Context, _ *gorm.DB, ids []uint, sentAt time.Time) error {
+	for _, id := range ids {
+		f.event(id).SentAt = &sentAt
+	}
+	return nil
+}
+
+func (f *fakeOutboxRepository) MarkFailed(
+	_ context.Context,
+	_ *gorm.DB,
+	id uint,
+	lastError string,
+	nextAttemptAt time.Time,
+) error {
+	event := f.event(id)
+	event.Attempts++
+	event.LastError = &lastError
+	event.NextAttemptAt = nextAttemptAt
+	return nil
+}
+
+func (f *fakeOutboxRepository) DeleteSentBefore(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (f *fakeOutboxRepository) event(id uint) *models.OutboxEvent {
+	for i := range f.events {
+		if f.events[i].ID == id {
+			return &f.events[i]
+		}
+	}
+	return nil
+}
+
+type fakeRegistry struct {
+	db     *gorm.DB
+	outbox *fakeOutboxRepository
+}
+
+func (r *fakeRegistry) GetField() fieldRepo.IFieldRepository { return nil }
+func (r *fakeRegistry) GetFieldSchedule() fieldScheduleRepo.IFieldScheduleRepository {
+	return nil
+}
+func (r *fakeRegistry) GetOutbox() outboxRepo.IOutboxRepository                { return r.outbox }
+func (r *fakeRegistry) GetPricingRule() pricingRuleRepo.IPricingRuleRepository { return nil }
+func (r *fakeRegistry) GetProcessedEvent() processedEventRepo.IProcessedEventRepository {
+	return nil
+}
+func (r *fakeRegistry) GetPromo() promoRepo.IPromoRepository { return nil }
+func (r *fakeRegistry) GetTime() timeRepo.ITimeRepository    { return nil }
+func (r *fakeRegistry) GetTx() *gorm.DB                      { return r.db }
+
+// fakePublisher fails to publish on the subjects in failing.
+type fakePublisher struct {
+	failing   map[string]bool
+	flushErr  error
+	published []string
+	flushes   int
+}
+
+func (p *fakePublisher) Publish(subject string, _ []byte) error {
+	if p.failing[subject] {
+		return errors.New("nats: connection closed")
+	}
+	p.published = append(p.published, subject)
+	return nil
+}
+
+func (p *fakePublisher) Flush() error {
+	p.flushes++
+	return p.flushErr
+}
+
+func newTestService(t *testing.T, events ...models.OutboxEvent) (IOutboxService, *fakeOutboxRepository) {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: &fakeConnPool{}}), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outbox := &fakeOutboxRepository{}
+	for i, event := range events {
+		event.ID = uint(i + 1)
+		outbox.events = append(outbox.events, event)
+	}
+
+	return NewOutboxService(&fakeRegistry{db: db, outbox: outbox}), outbox
+}
+
+func dueEvent(subject string) models.OutboxEvent {
+	return models.OutboxEvent{
+		UUID:          uuid.New(),
+		Subject:       subject,
+		Payload:       `{}`,
+		NextAttemptAt: time.Now().Add(-time.Second),
+	}
+}
+
+func TestRelayMarksPublishedEventsSent(t *testing.T) {
+	later := dueEvent("field_schedule.updated")
+	later.NextAttemptAt = time.Now().Add(time.Minute)
+	service, outbox := newTestService(t,
+		dueEvent("field_schedule.created"),
+		dueEvent("field_schedule.updated"),
+		later,
+	)
+	publisher := &fakePublisher{}
+
+	sent, err := service.Relay(context.Background(), publisher, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 2 || len(publisher.published) != 2 || publisher.flushes != 1 {
+		t.Fatalf("expected 2 events published and flushed once, got %d sent, %d published, %d flushes",
+			sent, len(publisher.published), publisher.flushes)
+	}
+
+	for _, event := range outbox.events[:2] {
+		if event.SentAt == nil {
+			t.Errorf("expected event %d to be marked sent", event.ID)
+		}
+	}
+	if outbox.events[2].SentAt != nil {
+		t.Error("expected the event that is not due yet to stay pending")
+	}
+}
+
+func TestRelayRespectsBatchSize(t *testing.T) {
+	service, _ := newTestService(t,
+		dueEvent("field_schedule.created"),
+		dueEvent("field_schedule.created"),
+		dueEvent("field_schedule.created"),
+	)
+
+	sent, err := service.Relay(context.Background(), &fakePublisher{}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 2 {
+		t.Errorf("expected a batch of 2, got %d", sent)
+	}
+}
+
+func TestRelayBacksOffFailedEvents(t *testing.T) {
+	failing := dueEvent("field_schedule.deleted")
+	failing.Attempts = 2
+	service, outbox := newTestService(t, failing, dueEvent("field_schedule.created"))
+	publisher := &fakePublisher{failing: map[string]bool{"field_schedule.deleted": true}}
+
+	before := time.Now()
+	sent, err := service.Relay(context.Background(), publisher, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 1 {
+		t.Fatalf("expected the healthy event to be sent, got %d", sent)
+	}
+
+	event := outbox.events[0]
+	if event.SentAt != nil || event.Attempts != 3 || event.LastError == nil {
+		t.Fatalf("expected the failed event to be marked failed, got %+v", event)
+	}
+	delay := event.NextAttemptAt.Sub(before)
+	if delay < 4*time.Second || delay > 5*time.Second {
+		t.Errorf("expected the third attempt to wait about 4s, got %s", delay)
+	}
+
+	// The failed event is not due again until its backoff has passed
+	publisher.failing = nil
+	sent, err = service.Relay(context.Background(), publisher, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 0 {
+		t.Errorf("expected nothing due during the backoff, got %d", sent)
+	}
+}
+
+func TestRelayKeepsEventsPendingWhenFlushFails(t *testing.T) {
+	service, outbox := newTestService(t, dueEvent("field_schedule.created"))
+	publisher := &fakePublisher{flushErr: errors.New("nats: timeout")}
+
+	_, err := service.Relay(context.Background(), publisher, 10)
+	if err == nil {
+		t.Fatal("expected the flush error to be returned")
+	}
+	if outbox.events[0].SentAt != nil {
+		t.Error("expected an unacknowledged event to stay pending")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	for attempts, expected := range map[int]time.Duration{
+		0:   time.Second,
+		1:   2 * time.Second,
+		3:   8 * time.Second,
+		8:   256 * time.Second,
+		9:   maxRetryDelay,
+		100: maxRetryDelay,
+	} {
+		if delay := retryDelay(attempts); delay != expected {
+			t.Errorf("retryDelay(%d) = %s, expected %s", attempts, delay, expected)
+		}
+	}
+}
+
+func TestRecordFieldScheduleEventsRequiresNATS(t *testing.T) {
+	schedules := []dto.FieldScheduleResponse{{UUID: uuid.New()}}
+	t.Cleanup(func() { config.Config.NATS.URL = "" })
+
+	for url, expected := range map[string]int{"": 0, "nats://localhost:4222": 1} {
+		config.Config.NATS.URL = url
+		service, outbox := newTestService(t)
+
+		err := service.RecordFieldScheduleEvents(context.Background(), nil, constants.FieldScheduleCreated, schedules)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(outbox.events) != expected {
+			t.Errorf("with nats url %q expected %d events, got %d", url, expected, len(outbox.events))
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	errWrap "field-service/common/error"
+	errFieldSchedule "field-service/constants/error/field_schedule"
+	errPricingRule "field-service/constants/error/pricing_rule"
+	errTime "field-service/constants/error/time"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	dateFormat = "2006-01-02"
+	timeFormat = "15:04:05"
+)
+
+type PricingRuleService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IPricingRuleService interface {
+	GetAll(context.Context, *dto.PricingRuleRequestParam) ([]dto.PricingRuleResponse, error)
+	GetByUUID(context.Context, string) (*dto.PricingRuleResponse, error)
+	Create(context.Context, *dto.PricingRuleRequest) (*dto.PricingRuleResponse, error)
+	Update(context.Context, string, *dto.PricingRuleRequest) (*dto.PricingRuleResponse, error)
+	Delete(context.Context, string) error
+}
+
+func NewPricingRuleService(repository repositories.IRepositoryRegistry) IPricingRuleService {
+	return &PricingRuleService{repository: repository}
+}
+
+// ResolvePrice returns the hourly price of a slot on the given date. Rules must
+// be ordered by evaluation order, as returned by FindAllByFieldID; the first
+// matching rule wins and the base price is used when none match.
+func ResolvePrice(rules []models.PricingRule, basePrice int, date time.Time, slot *models.Time) int {
+	dateString := date.Format(dateFormat)
+	weekday := int64(date.Weekday())
+	for _, rule := range rules {
+		if !slices.Contains(rule.DaysOfWeek, weekday) {
+			continue
+		}
+
+		if rule.StartDate != nil && dateString < rule.StartDate.Format(dateFormat) {
+			continue
+		}
+
+		if rule.EndDate != nil && dateString > rule.EndDate.Format(dateFormat) {
+			continue
+		}
+
+		if slot.StartTime < rule.StartTime || slot.StartTime >= rule.EndTime {
+			continue
+		}
+
+		return rule.PricePerHour
+	}
+
+	return basePrice
+}
+
+func formatDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+
+	result := date.Format(dateFormat)
+	return &result
+}
+
+func toPricingRuleResponse(pricingRule *models.PricingRule) dto.PricingRuleResponse {
+	daysOfWeek := make([]int, 0, len(pricingRule.DaysOfWeek))
+	for _, day := range pricingRule.DaysOfWeek {
+		daysOfWeek = append(daysOfWeek, int(day))
+	}
+
+	return dto.PricingRuleResponse{
+		UUID:         pricingRule.UUID,
+		FieldID:      pricingRule.Field.UUID,
+		FieldName:    pricingRule.Field.Name,
+		Name:         pricingRule.Name,
+		DaysOfWeek:   daysOfWeek,
+		StartTime:    pricingRule.StartTime,
+		EndTime:      pricingRule.EndTime,
+		StartDate:    formatDate(pricingRule.StartDate),
+		EndDate:      formatDate(pricingRule.EndDate),
+		PricePerHour: pricingRule.PricePerHour,
+		Priority:     pricingRule.Priority,
+		CreatedAt:    pricingRule.CreatedAt,
+		UpdatedAt:    pricingRule.UpdatedAt,
+	}
+}
+
+func parseDate(ctx context.Context, date *string) (*time.Time, error) {
+	if date == nil {
+		return nil, nil
+	}
+
+	result, err := time.Parse(dateFormat, *date)
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errFieldSchedule.ErrInvalidDateFormat)
+	}
+
+	return &result, nil
+}
+
+// buildPricingRule validates the request and converts it into a model that
+// belongs to the requested field.
+func (p *PricingRuleService) buildPricingRule(
+	ctx context.Context,
+	request *dto.PricingRuleRequest,
+) (*models.PricingRule, error) {
+	field, err := p.repository.GetField().FindByUUID(ctx, request.FieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	daysOfWeek := make([]int64, 0, len(request.DaysOfWeek))
+	for _, day := range request.DaysOfWeek {
+		if day < int(time.Sunday) || day > int(time.Saturday) {
+			return nil, errWrap.WrapErr(ctx, errPricingRule.ErrInvalidDaysOfWeek)
+		}
+		daysOfWeek = append(daysOfWeek, int64(day))
+	}
+	slices.Sort(daysOfWeek)
+	daysOfWeek = slices.Compact(daysOfWeek)
+
+	startTime, err := time.Parse(timeFormat, request.StartTime)
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errTime.ErrInvalidTimeFormat)
+	}
+
+	endTime, err := time.Parse(timeFormat, request.EndTime)
+	if err != nil {
+		return nil, errWrap.WrapErr(ctx, errTime.ErrInvalidTimeFormat)
+	}
+
+	if !endTime.After(startTime) {
+		return nil, errWrap.WrapErr(ctx, errTime.ErrInvalidTimeRange)
+	}
+
+	startDate, err := parseDate(ctx, request.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	endDate, err := parseDate(ctx, request.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if startDate != nil && endDate != nil && endDate.Before(*startDate) {
+		return nil, errWrap.WrapErr(ctx, errPricingRule.ErrInvalidDateRange)
+	}
+
+	return &models.PricingRule{
+		FieldID:      field.ID,
+		Name:         request.Name,
+		DaysOfWeek:   daysOfWeek,
+		StartTime:    startTime.Format(timeFormat),
+		EndTime:      endTime.Format(timeFormat),
+		StartDate:    startDate,
+		EndDate:      endDate,
+		PricePerHour: request.PricePerHour,
+		Priority:     request.Priority,
+	}, nil
+}
+
+func (p *PricingRuleService) GetAll(
+	ctx context.Context,
+	param *dto.PricingRuleRequestParam,
+) ([]dto.PricingRuleResponse, error) {
+	var fieldID *uint
+	if param.FieldID != nil {
+		field, err := p.repository.GetField().FindByUUID(ctx, *param.FieldID)
+		if err != nil {
+			return nil, err
+		}
+		fieldID = &field.ID
+	}
+
+	pricingRules, err := p.repository.GetPricingRule().FindAll(ctx, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	pricingRuleResults := make([]dto.PricingRuleResponse, 0, len(pricingRules))
+	for _, pricingRule := range pricingRules {
+		pricingRuleResults = append(pricingRuleResults, toPricingRuleResponse(&pricingRule))
+	}
+
+	return pricingRuleResults, nil
+}
+
+func (p *PricingRuleService) GetByUUID(ctx context.Context, uuid string) (*dto.PricingRuleResponse, error) {
+	pricingRule, err := p.repository.GetPricingRule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPricingRuleResponse(pricingRule)
+	return &response, nil
+}
+
+func (p *PricingRuleService) Create(
+	ctx context.Context,
+	request *dto.PricingRuleRequest,
+) (*dto.PricingRuleResponse, error) {
+	pricingRule, err := p.buildPricingRule(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	pricingRule.UUID = uuid.New()
+	result, err := p.repository.GetPricingRule().Create(ctx, pricingRule)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPricingRuleResponse(result)
+	return &response, nil
+}
+
+func (p *PricingRuleService) Update(
+	ctx context.Context,
+	uuid string,
+	request *dto.PricingRuleRequest,
+) (*dto.PricingRuleResponse, error) {
+	_, err := p.repository.GetPricingRule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	pricingRule, err := p.buildPricingRule(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := p.repository.GetPricingRule().Update(ctx, uuid, pricingRule)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPricingRuleResponse(result)
+	return &response, nil
+}
+
+func (p *PricingRuleService) Delete(ctx context.Context, uuid string) error {
+	_, err := p.repository.GetPricingRule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return p.repository.GetPricingRule().Delete(ctx, uuid)
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"field-service/domain/models"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const basePrice = 100000
+
+func date(value string) *time.Time {
+	result, err := time.Parse(dateFormat, value)
+	if err != nil {
+		panic(err)
+	}
+	return &result
+}
+
+// rules are in evaluation order. The holiday overlaps both of the others,
+// so only its position decides which price applies.
+var rules = []models.PricingRule{
+	{
+		Name:         "holiday",
+		DaysOfWeek:   pq.Int64Array{0, 1, 2, 3, 4, 5, 6},
+		StartTime:    "06:00:00",
+		EndTime:      "23:00:00",
+		StartDate:    date("2026-03-20"),
+		EndDate:      date("2026-03-22"),
+		PricePerHour: 200000,
+	},
+	{
+		Name:         "weekday evening",
+		DaysOfWeek:   pq.Int64Array{1, 2, 3, 4, 5},
+		StartTime:    "17:00:00",
+		EndTime:      "22:00:00",
+		PricePerHour: 150000,
+	},
+	{
+		Name:         "weekend",
+		DaysOfWeek:   pq.Int64Array{0, 6},
+		StartTime:    "06:00:00",
+		EndTime:      "23:00:00",
+		PricePerHour: 120000,
+	},
+}
+
+func TestResolvePrice(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		rules     []models.PricingRule
+		date      string
+		startTime string
+		price     int
+	}{
+		{name: "first match wins", rules: rules, date: "2026-03-20", startTime: "18:00:00", price: 200000},
+		{name: "first match wins on a weekend", rules: rules, date: "2026-03-21", startTime: "18:00:00", price: 200000},
+		{name: "start date is inclusive", rules: rules, date: "2026-03-20", startTime: "10:00:00", price: 200000},
+		{name: "end date is inclusive", rules: rules, date: "2026-03-22", startTime: "10:00:00", price: 200000},
+		{name: "day before start date", rules: rules, date: "2026-03-19", startTime: "10:00:00", price: basePrice},
+		{name: "day after end date", rules: rules, date: "2026-03-23", startTime: "10:00:00", price: basePrice},
+		{name: "slot at start time", rules: rules, date: "2026-03-02", startTime: "17:00:00", price: 150000},
+		{name: "slot before start time", rules: rules, date: "2026-03-02", startTime: "16:00:00", price: basePrice},
+		{name: "last slot before end time", rules: rules, date: "2026-03-02", startTime: "21:00:00", price: 150000},
+		{name: "slot at end time", rules: rules, date: "2026-03-02", startTime: "22:00:00", price: basePrice},
+		{name: "weekday outside days of week", rules: rules, date: "2026-03-07", startTime: "18:00:00", price: 120000},
+		{name: "no rule matches", rules: rules, date: "2026-03-07", startTime: "05:00:00", price: basePrice},
+		{name: "no rules", date: "2026-03-02", startTime: "18:00:00", price: basePrice},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			price := ResolvePrice(tc.rules, basePrice, *date(tc.date), &models.Time{StartTime: tc.startTime})
+			if price != tc.price {
+				t.Errorf("expected %d, got %d", tc.price, price)
+			}
+		})
+	}
+}
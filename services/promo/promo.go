@@ -0,0 +1,469 @@
+package services
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	"field-service/common/utils"
+	"field-service/constants"
+	errFieldSchedule "field-service/constants/error/field_schedule"
+	errPromo "field-service/constants/error/promo"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	pricingRuleService "field-service/services/pricing_rule"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const dateFormat = "2006-01-02"
+
+type PromoService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IPromoService interface {
+	GetAll(context.Context) ([]dto.PromoResponse, error)
+	GetByUUID(context.Context, string) (*dto.PromoResponse, error)
+	Create(context.Context, *dto.PromoRequest) (*dto.PromoResponse, error)
+	Update(context.Context, string, *dto.PromoRequest) (*dto.PromoResponse, error)
+	Delete(context.Context, string) error
+	Quote(context.Context, *dto.PromoQuoteRequest) (*dto.PromoQuoteResponse, error)
+	Redeem(context.Context, *gorm.DB, *dto.PromoRedeemParam) error
+}
+
+// quote is the priced result of a set of field schedules, shared by the
+// quote endpoint and redemption so both always agree on the numbers.
+type quote struct {
+	fieldSchedules []models.FieldSchedule
+	prices         []int
+	promo          *models.Promo
+	userID         *uuid.UUID
+	subtotal       int
+	discount       int
+}
+
+func NewPromoService(repository repositories.IRepositoryRegistry) IPromoService {
+	return &PromoService{repository: repository}
+}
+
+func toPromoResponse(promo *models.Promo) dto.PromoResponse {
+	allowedFieldIDs := []string(promo.AllowedFieldIDs)
+	if allowedFieldIDs == nil {
+		allowedFieldIDs = []string{}
+	}
+
+	return dto.PromoResponse{
+		UUID:            promo.UUID,
+		Code:            promo.Code,
+		Name:            promo.Name,
+		DiscountType:    promo.DiscountType,
+		DiscountValue:   promo.DiscountValue,
+		ValidFrom:       promo.ValidFrom,
+		ValidUntil:      promo.ValidUntil,
+		MaxUsage:        promo.MaxUsage,
+		MaxUsagePerUser: promo.MaxUsagePerUser,
+		AllowedFieldIDs: allowedFieldIDs,
+		CreatedAt:       promo.CreatedAt,
+		UpdatedAt:       promo.UpdatedAt,
+	}
+}
+
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (p *PromoService) buildPromo(ctx context.Context, request *dto.PromoRequest) (*models.Promo, error) {
+	discountType := constants.PromoDiscountType(strings.ToLower(request.DiscountType))
+	switch discountType {
+	case constants.Percentage:
+		if request.DiscountValue < 1 || request.DiscountValue > 100 {
+			return nil, errWrap.WrapErr(ctx, errPromo.ErrInvalidDiscountValue)
+		}
+	case constants.Fixed:
+		if request.DiscountValue < 1 {
+			return nil, errWrap.WrapErr(ctx, errPromo.ErrInvalidDiscountValue)
+		}
+	default:
+		return nil, errWrap.WrapErr(ctx, errPromo.ErrInvalidDiscountType)
+	}
+
+	if !request.ValidUntil.After(request.ValidFrom) {
+		return nil, errWrap.WrapErr(ctx, errPromo.ErrInvalidPromoPeriod)
+	}
+
+	if (request.MaxUsage != nil && *request.MaxUsage < 1) ||
+		(request.MaxUsagePerUser != nil && *request.MaxUsagePerUser < 1) {
+		return nil, errWrap.WrapErr(ctx, errPromo.ErrInvalidDiscountValue)
+	}
+
+	allowedFieldIDs := make([]string, 0, len(request.AllowedFieldIDs))
+	for _, fieldID := range request.AllowedFieldIDs {
+		field, err := p.repository.GetField().FindByUUID(ctx, fieldID)
+		if err != nil {
+			return nil, err
+		}
+		allowedFieldIDs = append(allowedFieldIDs, field.UUID.String())
+	}
+
+	return &models.Promo{
+		Code:            normalizeCode(request.Code),
+		Name:            request.Name,
+		DiscountType:    discountType,
+		DiscountValue:   request.DiscountValue,
+		ValidFrom:       request.ValidFrom,
+		ValidUntil:      request.ValidUntil,
+		MaxUsage:        request.MaxUsage,
+		MaxUsagePerUser: request.MaxUsagePerUser,
+		AllowedFieldIDs: allowedFieldIDs,
+	}, nil
+}
+
+func (p *PromoService) GetAll(ctx context.Context) ([]dto.PromoResponse, error) {
+	promos, err := p.repository.GetPromo().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	promoResults := make([]dto.PromoResponse, 0, len(promos))
+	for _, promo := range promos {
+		promoResults = append(promoResults, toPromoResponse(&promo))
+	}
+
+	return promoResults, nil
+}
+
+func (p *PromoService) GetByUUID(ctx context.Context, uuid string) (*dto.PromoResponse, error) {
+	promo, err := p.repository.GetPromo().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPromoResponse(promo)
+	return &response, nil
+}
+
+func (p *PromoService) Create(ctx context.Context, request *dto.PromoRequest) (*dto.PromoResponse, error) {
+	promo, err := p.buildPromo(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := p.repository.GetPromo().FindByCode(ctx, p.repository.GetTx(), promo.Code, false)
+	if err != nil && !errors.Is(err, errPromo.ErrPromoNotFound) {
+		return nil, err
+	}
+
+	if existing != nil {
+		return nil, errWrap.WrapErr(ctx, errPromo.ErrPromoCodeIsExist)
+	}
+
+	promo.UUID = uuid.New()
+	result, err := p.repository.GetPromo().Create(ctx, promo)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPromoResponse(result)
+	return &response, nil
+}
+
+func (p *PromoService) Update(
+	ctx context.Context,
+	uuid string,
+	request *dto.PromoRequest,
+) (*dto.PromoResponse, error) {
+	current, err := p.repository.GetPromo().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	promo, err := p.buildPromo(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if promo.Code != current.Code {
+		existing, err := p.repository.GetPromo().FindByCode(ctx, p.repository.GetTx(), promo.Code, false)
+		if err != nil && !errors.Is(err, errPromo.ErrPromoNotFound) {
+			return nil, err
+		}
+
+		if existing != nil {
+			return nil, errWrap.WrapErr(ctx, errPromo.ErrPromoCodeIsExist)
+		}
+	}
+
+	result, err := p.repository.GetPromo().Update(ctx, uuid, promo)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toPromoResponse(result)
+	return &response, nil
+}
+
+// Delete removes a promo that was never redeemed. A redeemed promo is ended
+// instead, since deleting it would cascade to its redemptions.
+func (p *PromoService) Delete(ctx context.Context, uuid string) error {
+	return p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		promo, err := p.repository.GetPromo().FindByUUIDForUpdate(ctx, tx, uuid)
+		if err != nil {
+			return err
+		}
+
+		total, err := p.repository.GetPromo().CountRedemptions(ctx, tx, promo.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		if total > 0 {
+			return p.repository.GetPromo().End(ctx, tx, promo.ID, time.Now())
+		}
+		return p.repository.GetPromo().Delete(ctx, tx, promo.ID)
+	})
+}
+
+func normalizeUUIDs(ctx context.Context, ids []string) ([]string, error) {
+	uuids := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return nil, errWrap.WrapErr(ctx, fmt.Errorf("%w: %s", errFieldSchedule.ErrFieldScheduleNotFound, id))
+		}
+		uuids = append(uuids, parsed.String())
+	}
+	slices.Sort(uuids)
+
+	return slices.Compact(uuids), nil
+}
+
+func discountAmount(promo *models.Promo, subtotal int) int {
+	var discount int
+	switch promo.DiscountType {
+	case constants.Percentage:
+		discount = subtotal * promo.DiscountValue / 100
+	case constants.Fixed:
+		discount = promo.DiscountValue
+	}
+
+	return min(discount, subtotal)
+}
+
+// validatePromo checks the promo window, field restrictions and usage caps.
+func (p *PromoService) validatePromo(
+	ctx context.Context,
+	tx *gorm.DB,
+	promo *models.Promo,
+	fieldSchedules []models.FieldSchedule,
+	userID *uuid.UUID,
+) error {
+	now := time.Now()
+	if now.Before(promo.ValidFrom) || now.After(promo.ValidUntil) {
+		return errWrap.WrapErr(ctx, errPromo.ErrPromoNotActive)
+	}
+
+	if len(promo.AllowedFieldIDs) > 0 {
+		for _, fieldSchedule := range fieldSchedules {
+			if !slices.Contains(promo.AllowedFieldIDs, fieldSchedule.Field.UUID.String()) {
+				return errWrap.WrapErr(ctx, errPromo.ErrPromoNotApplicable)
+			}
+		}
+	}
+
+	if promo.MaxUsage != nil {
+		total, err := p.repository.GetPromo().CountRedemptions(ctx, tx, promo.ID, nil)
+		if err != nil {
+			return err
+		}
+
+		if total >= int64(*promo.MaxUsage) {
+			return errWrap.WrapErr(ctx, errPromo.ErrPromoUsageExceeded)
+		}
+	}
+
+	if promo.MaxUsagePerUser != nil {
+		if userID == nil {
+			return errWrap.WrapErr(ctx, errPromo.ErrPromoUserRequired)
+		}
+
+		total, err := p.repository.GetPromo().CountRedemptions(ctx, tx, promo.ID, userID)
+		if err != nil {
+			return err
+		}
+
+		if total >= int64(*promo.MaxUsagePerUser) {
+			return errWrap.WrapErr(ctx, errPromo.ErrPromoUsageExceeded)
+		}
+	}
+
+	return nil
+}
+
+// calculate prices the schedules and applies the promo code, if any. With
+// forUpdate set the promo row is locked so that the usage caps hold for
+// concurrent redemptions.
+func (p *PromoService) calculate(
+	ctx context.Context,
+	tx *gorm.DB,
+	ids []string,
+	promoCode, userIDParam *string,
+	forUpdate bool,
+) (*quote, error) {
+	uuids, err := normalizeUUIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var userID *uuid.UUID
+	if userIDParam != nil {
+		parsed, err := uuid.Parse(*userIDParam)
+		if err != nil {
+			return nil, errWrap.WrapErr(ctx, errPromo.ErrInvalidUserID)
+		}
+		userID = &parsed
+	}
+
+	fieldSchedules, err := p.repository.GetFieldSchedule().FindByUUIDs(ctx, tx, uuids)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]struct{}, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		found[fieldSchedule.UUID.String()] = struct{}{}
+	}
+
+	missing := make([]string, 0)
+	for _, id := range uuids {
+		if _, ok := found[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, errWrap.WrapErr(ctx, fmt.Errorf("%w: %s",
+			errFieldSchedule.ErrFieldScheduleNotFound, strings.Join(missing, ", ")))
+	}
+
+	result := &quote{
+		fieldSchedules: fieldSchedules,
+		prices:         make([]int, 0, len(fieldSchedules)),
+		userID:         userID,
+	}
+
+	pricingRules := make(map[uint][]models.PricingRule)
+	for _, fieldSchedule := range fieldSchedules {
+		rules, ok := pricingRules[fieldSchedule.FieldID]
+		if !ok {
+			rules, err = p.repository.GetPricingRule().FindAllByFieldID(ctx, fieldSchedule.FieldID)
+			if err != nil {
+				return nil, err
+			}
+			pricingRules[fieldSchedule.FieldID] = rules
+		}
+
+		price := pricingRuleService.ResolvePrice(
+			rules,
+			fieldSchedule.Field.PricePerHour,
+			fieldSchedule.Date,
+			&fieldSchedule.Time,
+		)
+		result.prices = append(result.prices, price)
+		result.subtotal += price
+	}
+
+	if promoCode == nil || strings.TrimSpace(*promoCode) == "" {
+		return result, nil
+	}
+
+	promo, err := p.repository.GetPromo().FindByCode(ctx, tx, normalizeCode(*promoCode), forUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.validatePromo(ctx, tx, promo, fieldSchedules, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result.promo = promo
+	result.discount = discountAmount(promo, result.subtotal)
+	return result, nil
+}
+
+func (p *PromoService) Quote(ctx context.Context, request *dto.PromoQuoteRequest) (*dto.PromoQuoteResponse, error) {
+	result, err := p.calculate(
+		ctx,
+		p.repository.GetTx(),
+		request.FieldScheduleIDs,
+		request.PromoCode,
+		request.UserID,
+		false,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.PromoQuoteItemResponse, 0, len(result.fieldSchedules))
+	for i, fieldSchedule := range result.fieldSchedules {
+		price := float64(result.prices[i])
+		items = append(items, dto.PromoQuoteItemResponse{
+			UUID:         fieldSchedule.UUID,
+			FieldName:    fieldSchedule.Field.Name,
+			Date:         fieldSchedule.Date.Format(dateFormat),
+			Time:         fmt.Sprintf("%s - %s", fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime),
+			PricePerHour: utils.GenerateRupiahFormat(&price),
+		})
+	}
+
+	var promoCode *string
+	if result.promo != nil {
+		promoCode = &result.promo.Code
+	}
+
+	subtotal := float64(result.subtotal)
+	discount := float64(result.discount)
+	total := float64(result.subtotal - result.discount)
+	return &dto.PromoQuoteResponse{
+		Items:       items,
+		PromoCode:   promoCode,
+		Subtotal:    utils.GenerateRupiahFormat(&subtotal),
+		Discount:    utils.GenerateRupiahFormat(&discount),
+		Total:       utils.GenerateRupiahFormat(&total),
+		TotalAmount: result.subtotal - result.discount,
+	}, nil
+}
+
+// Redeem records a promo redemption inside tx. It is meant to run in the same
+// transaction that marks the schedules as booked.
+func (p *PromoService) Redeem(ctx context.Context, tx *gorm.DB, param *dto.PromoRedeemParam) error {
+	result, err := p.calculate(ctx, tx, param.FieldScheduleIDs, &param.PromoCode, param.UserID, true)
+	if err != nil {
+		return err
+	}
+
+	if result.promo == nil {
+		return nil
+	}
+
+	fieldScheduleIDs := make([]string, 0, len(result.fieldSchedules))
+	for _, fieldSchedule := range result.fieldSchedules {
+		fieldScheduleIDs = append(fieldScheduleIDs, fieldSchedule.UUID.String())
+	}
+
+	return p.repository.GetPromo().CreateRedemption(ctx, tx, &models.PromoRedemption{
+		UUID:             uuid.New(),
+		PromoID:          result.promo.ID,
+		UserID:           result.userID,
+		OrderID:          param.OrderID,
+		FieldScheduleIDs: fieldScheduleIDs,
+		Subtotal:         result.subtotal,
+		Discount:         result.discount,
+	})
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"field-service/common/storage"
+	"field-service/repositories"
+	fieldService "field-service/services/field"
+	fieldScheduleService "field-service/services/field_schedule"
+	outboxService "field-service/services/outbox"
+	pricingRuleService "field-service/services/pricing_rule"
+	promoService "field-service/services/promo"
+	timeService "field-service/services/time"
+)
+
+type Registry struct {
+	repository repositories.IRepositoryRegistry
+	storage    storage.IStorageClient
+}
+
+type IServiceRegistry interface {
+	GetField() fieldService.IFieldService
+	GetFieldSchedule() fieldScheduleService.IFieldScheduleService
+	GetOutbox() outboxService.IOutboxService
+	GetPricingRule() pricingRuleService.IPricingRuleService
+	GetPromo() promoService.IPromoService
+	GetTime() timeService.ITimeService
+}
+
+func NewServiceRegistry(repository repositories.IRepositoryRegistry, storage storage.IStorageClient) IServiceRegistry {
+	return &Registry{
+		repository: repository,
+		storage:    storage,
+	}
+}
+
+func (r *Registry) GetField() fieldService.IFieldService {
+	return fieldService.NewFieldService(r.repository, r.storage)
+}
+
+func (r *Registry) GetTime() timeService.ITimeService {
+	return timeService.NewTimeService(r.repository)
+}
+
+func (r *Registry) GetFieldSchedule() fieldScheduleService.IFieldScheduleService {
+	return fieldScheduleService.NewFieldScheduleService(r.repository)
+}
+
+func (r *Registry) GetOutbox() outboxService.IOutboxService {
+	return outboxService.NewOutboxService(r.repository)
+}
+
+func (r *Registry) GetPricingRule() pricingRuleService.IPricingRuleService {
+	return pricingRuleService.NewPricingRuleService(r.repository)
+}
+
+func (r *Registry) GetPromo() promoService.IPromoService {
+	return promoService.NewPromoService(r.repository)
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	errWrap "field-service/common/error"
+	errTime "field-service/constants/error/time"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const timeFormat = "15:04:05"
+
+type TimeService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type ITimeService interface {
+	GetAll(context.Context) ([]dto.TimeResponse, error)
+	GetByUUID(context.Context, string) (*dto.TimeResponse, error)
+	Create(context.Context, *dto.TimeRequest) (*dto.TimeResponse, error)
+	Update(context.Context, string, *dto.TimeRequest) (*dto.TimeResponse, error)
+}
+
+func NewTimeService(repository repositories.IRepositoryRegistry) ITimeService {
+	return &TimeService{repository: repository}
+}
+
+func toTimeResponse(t *models.Time) dto.TimeResponse {
+	return dto.TimeResponse{
+		UUID:      t.UUID,
+		StartTime: t.StartTime,
+		EndTime:   t.EndTime,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+func validateTimeRange(ctx context.Context, request *dto.TimeRequest) error {
+	startTime, err := time.Parse(timeFormat, request.StartTime)
+	if err != nil {
+		return errWrap.WrapErr(ctx, errTime.ErrInvalidTimeFormat)
+	}
+
+	endTime, err := time.Parse(timeFormat, request.EndTime)
+	if err != nil {
+		return errWrap.WrapErr(ctx, errTime.ErrInvalidTimeFormat)
+	}
+
+	if !endTime.After(startTime) {
+		return errWrap.WrapErr(ctx, errTime.ErrInvalidTimeRange)
+	}
+
+	return nil
+}
+
+func (t *TimeService) GetAll(ctx context.Context) ([]dto.TimeResponse, error) {
+	times, err := t.repository.GetTime().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	timeResults := make([]dto.TimeResponse, 0, len(times))
+	for _, item := range times {
+		timeResults = append(timeResults, toTimeResponse(&item))
+	}
+
+	return timeResults, nil
+}
+
+func (t *TimeService) GetByUUID(ctx context.Context, uuid string) (*dto.TimeResponse, error) {
+	result, err := t.repository.GetTime().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toTimeResponse(result)
+	return &response, nil
+}
+
+func (t *TimeService) Create(ctx context.Context, request *dto.TimeRequest) (*dto.TimeResponse, error) {
+	err := validateTimeRange(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	overlap, err := t.repository.GetTime().FindOverlap(ctx, request.StartTime, request.EndTime, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if overlap != nil {
+		return nil, errWrap.WrapErr(ctx, errTime.ErrTimeIsExist)
+	}
+
+	result, err := t.repository.GetTime().Create(ctx, &models.Time{
+		UUID:      uuid.New(),
+		StartTime: request.StartTime,
+		EndTime:   request.EndTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toTimeResponse(result)
+	return &response, nil
+}
+
+func (t *TimeService) Update(
+	ctx context.Context,
+	uuid string,
+	request *dto.TimeRequest,
+) (*dto.TimeResponse, error) {
+	_, err := t.repository.GetTime().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateTimeRange(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	overlap, err := t.repository.GetTime().FindOverlap(ctx, request.StartTime, request.EndTime, &uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	if overlap != nil {
+		return nil, errWrap.WrapErr(ctx, errTime.ErrTimeIsExist)
+	}
+
+	result, err := t.repository.GetTime().Update(ctx, uuid, &models.Time{
+		StartTime: request.StartTime,
+		EndTime:   request.EndTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := toTimeResponse(result)
+	return &response, nil
+}
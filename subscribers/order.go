@@ -0,0 +1,298 @@
+package subscribers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"field-service/common/logger"
+	"field-service/common/tracing"
+	"field-service/constants"
+	errFieldSchedule "field-service/constants/error/field_schedule"
+	"field-service/domain/dto"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nats-io/nats.go"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	handleTimeout = 30 * time.Second
+
+	// ackWait leaves the handler its full timeout before the server assumes
+	// the delivery was lost and redelivers it.
+	ackWait = 2 * handleTimeout
+
+	maxRetryDelay = 5 * time.Minute
+
+	// msgIDHeader is used as the event id when the payload does not carry one.
+	msgIDHeader = "Nats-Msg-Id"
+)
+
+var validate = validator.New()
+
+type OrderSubjects struct {
+	Paid      string
+	Cancelled string
+	Expired   string
+}
+
+// OrderConsumer configures the JetStream consumers. Each subject gets a
+// durable consumer named after the queue group, so replicas share the
+// deliveries and unacknowledged events survive restarts.
+type OrderConsumer struct {
+	Stream     string
+	QueueGroup string
+	MaxDeliver int
+	RetryDelay time.Duration
+}
+
+// IOrderHandler applies order events. Handlers must be idempotent per event
+// id, since a failed or unacknowledged event is delivered again.
+type IOrderHandler interface {
+	HandleOrderPaid(context.Context, string, *dto.OrderEvent) error
+	HandleOrderReleased(context.Context, string, *dto.OrderEvent) error
+}
+
+type OrderSubscriber struct {
+	conn          *nats.Conn
+	handler       IOrderHandler
+	subjects      OrderSubjects
+	consumer      OrderConsumer
+	subscriptions []*nats.Subscription
+}
+
+type IOrderSubscriber interface {
+	Start() error
+	Close() error
+}
+
+func NewOrderSubscriber(
+	conn *nats.Conn,
+	handler IOrderHandler,
+	subjects OrderSubjects,
+	consumer OrderConsumer,
+) IOrderSubscriber {
+	return &OrderSubscriber{
+		conn:     conn,
+		handler:  handler,
+		subjects: subjects,
+		consumer: consumer,
+	}
+}
+
+// Start creates the order stream when it does not exist yet and subscribes
+// to the order events with explicit acknowledgement. Events that fail are
+// redelivered with backoff up to MaxDeliver times.
+func (o *OrderSubscriber) Start() error {
+	js, err := o.conn.JetStream()
+	if err != nil {
+		return err
+	}
+
+	err = o.ensureStream(js)
+	if err != nil {
+		return err
+	}
+
+	handlers := map[string]func(context.Context, string, *dto.OrderEvent) error{
+		o.subjects.Paid:      o.handler.HandleOrderPaid,
+		o.subjects.Cancelled: o.handler.HandleOrderReleased,
+		o.subjects.Expired:   o.handler.HandleOrderReleased,
+	}
+
+	for subject, handler := range handlers {
+		durable := durableName(o.consumer.QueueGroup, subject)
+		err = o.ensureConsumer(js, subject, durable)
+		if err != nil {
+			_ = o.Close()
+			return err
+		}
+
+		// Binding keeps the library from deleting the shared consumer when
+		// this replica drains its subscription
+		subscription, err := js.QueueSubscribe(
+			subject,
+			o.consumer.QueueGroup,
+			o.handle(handler),
+			nats.Bind(o.consumer.Stream, durable),
+			nats.ManualAck(),
+		)
+		if err != nil {
+			_ = o.Close()
+			return err
+		}
+		o.subscriptions = append(o.subscriptions, subscription)
+	}
+
+	return nil
+}
+
+func (o *OrderSubscriber) ensureStream(js nats.JetStreamContext) error {
+	_, err := js.StreamInfo(o.consumer.Stream)
+	if !errors.Is(err, nats.ErrStreamNotFound) {
+		return err
+	}
+
+	_, err = js.AddStream(&nats.StreamConfig{
+		Name:     o.consumer.Stream,
+		Subjects: []string{o.subjects.Paid, o.subjects.Cancelled, o.subjects.Expired},
+	})
+	return err
+}
+
+// ensureConsumer creates the durable consumer of subject unless a replica
+// already did. Its deliver subject is derived from the name so every replica
+// creates the same consumer.
+func (o *OrderSubscriber) ensureConsumer(js nats.JetStreamContext, subject, durable string) error {
+	_, err := js.ConsumerInfo(o.consumer.Stream, durable)
+	if !errors.Is(err, nats.ErrConsumerNotFound) {
+		return err
+	}
+
+	_, err = js.AddConsumer(o.consumer.Stream, &nats.ConsumerConfig{
+		Durable:        durable,
+		DeliverSubject: "deliver." + durable,
+		DeliverGroup:   o.consumer.QueueGroup,
+		DeliverPolicy:  nats.DeliverAllPolicy,
+		FilterSubject:  subject,
+		AckPolicy:      nats.AckExplicitPolicy,
+		AckWait:        ackWait,
+		MaxDeliver:     o.consumer.MaxDeliver,
+	})
+	return err
+}
+
+// durableName derives a consumer name per subject, since consumer names may
+// not contain dots.
+func durableName(queueGroup, subject string) string {
+	return queueGroup + "-" + strings.ReplaceAll(subject, ".", "-")
+}
+
+// retryDelay doubles the delay with every delivery, up to maxRetryDelay.
+func (o *OrderSubscriber) retryDelay(delivered uint64) time.Duration {
+	delay := o.consumer.RetryDelay
+	for i := uint64(1); i < delivered && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
+
+func (o *OrderSubscriber) handle(
+	handler func(context.Context, string, *dto.OrderEvent) error,
+) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		var event dto.OrderEvent
+		err := json.Unmarshal(msg.Data, &event)
+		if err != nil {
+			logrus.Errorf("invalid %s event: %v", msg.Subject, err)
+			_ = msg.Term()
+			return
+		}
+
+		if event.EventID == "" && msg.Header != nil {
+			event.EventID = msg.Header.Get(msgIDHeader)
+		}
+
+		err = validate.Struct(event)
+		if err != nil {
+			logrus.Errorf("invalid %s event: %v", msg.Subject, err)
+			_ = msg.Term()
+			return
+		}
+
+		// The event id doubles as the request id so every log line written
+		// while handling the event can be traced back to it.
+		requestID := event.EventID
+		if msg.Header != nil && msg.Header.Get(constants.XRequestID) != "" {
+			requestID = msg.Header.Get(constants.XRequestID)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), handleTimeout)
+		defer cancel()
+		ctx = logger.WithRequestID(ctx, requestID)
+
+		// Continue the trace of the order-service request that published the event
+		if msg.Header != nil {
+			ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(msg.Header))
+		}
+		ctx, span := tracing.Tracer().Start(ctx, msg.Subject+" process",
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				attribute.String("messaging.system", "nats"),
+				attribute.String("messaging.destination.name", msg.Subject),
+				attribute.String("messaging.message.id", event.EventID),
+			),
+		)
+		defer span.End()
+
+		err = handler(ctx, msg.Subject, &event)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			o.retry(ctx, msg, &event, err)
+			return
+		}
+
+		err = msg.Ack()
+		if err != nil {
+			logger.FromContext(ctx).Errorf("failed to ack %s event %s: %v", msg.Subject, event.EventID, err)
+			return
+		}
+
+		logger.FromContext(ctx).Infof("handled %s event %s for order %s", msg.Subject, event.EventID, event.OrderID)
+	}
+}
+
+// isPermanent reports field schedule errors, such as an already booked
+// schedule, that would fail the same way on every redelivery. Database
+// errors are not among them and are retried.
+func isPermanent(err error) bool {
+	return slices.ContainsFunc(errFieldSchedule.FieldScheduleErrors, func(target error) bool {
+		return errors.Is(err, target)
+	})
+}
+
+// retry asks for a delayed redelivery of a failed event. Permanent errors and
+// events on their last delivery are terminated instead.
+func (o *OrderSubscriber) retry(ctx context.Context, msg *nats.Msg, event *dto.OrderEvent, err error) {
+	var delivered uint64 = 1
+	metadata, metadataErr := msg.Metadata()
+	if metadataErr == nil {
+		delivered = metadata.NumDelivered
+	}
+
+	if isPermanent(err) || delivered >= uint64(o.consumer.MaxDeliver) {
+		logger.FromContext(ctx).Errorf(
+			"dropping %s event %s after %d deliveries: %v", msg.Subject, event.EventID, delivered, err,
+		)
+		_ = msg.Term()
+		return
+	}
+
+	delay := o.retryDelay(delivered)
+	logger.FromContext(ctx).Warnf(
+		"failed to handle %s event %s, retrying in %s: %v", msg.Subject, event.EventID, delay, err,
+	)
+	_ = msg.NakWithDelay(delay)
+}
+
+// Close drains the subscriptions so in-flight messages finish first.
+func (o *OrderSubscriber) Close() error {
+	for _, subscription := range o.subscriptions {
+		err := subscription.Drain()
+		if err != nil {
+			return err
+		}
+	}
+	o.subscriptions = nil
+
+	return nil
+}
@@ -0,0 +1,272 @@
+package subscribers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	errFieldSchedule "field-service/constants/error/field_schedule"
+	"field-service/domain/dto"
+	"sync"
+	"testing"
+	"time"
+
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+)
+
+var testSubjects = OrderSubjects{
+	Paid:      "order.paid",
+	Cancelled: "order.cancelled",
+	Expired:   "order.expired",
+}
+
+var errTransient = errors.New("connection reset")
+
+// fakeHandler applies events to an in-memory slot table and skips event ids
+// it has seen, like the processed_events check in the service.
+type fakeHandler struct {
+	mutex     sync.Mutex
+	processed map[string]bool
+	slots     map[string]string
+	calls     map[string]int
+	failures  map[string][]error
+}
+
+func newFakeHandler() *fakeHandler {
+	return &fakeHandler{
+		processed: make(map[string]bool),
+		slots:     make(map[string]string),
+		calls:     make(map[string]int),
+		failures:  make(map[string][]error),
+	}
+}
+
+// failWith makes the next deliveries of eventID return errs in order.
+func (f *fakeHandler) failWith(eventID string, errs ...error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.failures[eventID] = errs
+}
+
+func (f *fakeHandler) apply(event *dto.OrderEvent, orderID string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.calls[event.EventID]++
+	if failures := f.failures[event.EventID]; len(failures) > 0 {
+		f.failures[event.EventID] = failures[1:]
+		return failures[0]
+	}
+
+	if f.processed[event.EventID] {
+		return nil
+	}
+	f.processed[event.EventID] = true
+
+	for _, id := range event.FieldScheduleIDs {
+		f.slots[id] = orderID
+	}
+	return nil
+}
+
+func (f *fakeHandler) HandleOrderPaid(_ context.Context, _ string, event *dto.OrderEvent) error {
+	return f.apply(event, event.OrderID)
+}
+
+func (f *fakeHandler) HandleOrderReleased(_ context.Context, _ string, event *dto.OrderEvent) error {
+	return f.apply(event, "")
+}
+
+func (f *fakeHandler) slot(id string) string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.slots[id]
+}
+
+func (f *fakeHandler) callCount(eventID string) int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.calls[eventID]
+}
+
+// startSubscriber runs an embedded JetStream server and an order subscriber
+// connected to it.
+func startSubscriber(t *testing.T, handler IOrderHandler) nats.JetStreamContext {
+	t.Helper()
+
+	options := natsserver.DefaultTestOptions
+	options.Port = -1
+	options.JetStream = true
+	options.StoreDir = t.TempDir()
+	server := natsserver.RunServer(&options)
+	t.Cleanup(server.Shutdown)
+
+	conn, err := nats.Connect(server.ClientURL())
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(conn.Close)
+
+	subscriber := NewOrderSubscriber(conn, handler, testSubjects, OrderConsumer{
+		Stream:     "ORDERS",
+		QueueGroup: "field-service",
+		MaxDeliver: 3,
+		RetryDelay: 10 * time.Millisecond,
+	})
+	err = subscriber.Start()
+	if err != nil {
+		t.Fatalf("start subscriber: %v", err)
+	}
+	t.Cleanup(func() { _ = subscriber.Close() })
+
+	js, err := conn.JetStream()
+	if err != nil {
+		t.Fatalf("jetstream: %v", err)
+	}
+	return js
+}
+
+func publish(t *testing.T, js nats.JetStreamContext, subject string, event dto.OrderEvent) {
+	t.Helper()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("marshal event: %v", err)
+	}
+
+	_, err = js.Publish(subject, data)
+	if err != nil {
+		t.Fatalf("publish %s: %v", subject, err)
+	}
+}
+
+// waitSettled waits until the consumer of subject has acknowledged or
+// terminated every message.
+func waitSettled(t *testing.T, js nats.JetStreamContext, subject string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		info, err := js.ConsumerInfo("ORDERS", durableName("field-service", subject))
+		if err == nil && info.NumPending == 0 && info.NumAckPending == 0 && info.NumRedelivered == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("messages on %s were not settled in time", subject)
+}
+
+func TestOrderSubscriberBooksPaidOrder(t *testing.T) {
+	handler := newFakeHandler()
+	js := startSubscriber(t, handler)
+
+	publish(t, js, testSubjects.Paid, dto.OrderEvent{
+		EventID:          "event-1",
+		OrderID:          "order-1",
+		FieldScheduleIDs: []string{"slot-1", "slot-2"},
+	})
+	waitSettled(t, js, testSubjects.Paid)
+
+	for _, slot := range []string{"slot-1", "slot-2"} {
+		if got := handler.slot(slot); got != "order-1" {
+			t.Errorf("expected %s to be booked by order-1, got %q", slot, got)
+		}
+	}
+}
+
+func TestOrderSubscriberReleasesSlots(t *testing.T) {
+	for _, subject := range []string{testSubjects.Cancelled, testSubjects.Expired} {
+		t.Run(subject, func(t *testing.T) {
+			handler := newFakeHandler()
+			js := startSubscriber(t, handler)
+
+			publish(t, js, testSubjects.Paid, dto.OrderEvent{
+				EventID:          "event-paid",
+				OrderID:          "order-1",
+				FieldScheduleIDs: []string{"slot-1"},
+			})
+			waitSettled(t, js, testSubjects.Paid)
+
+			publish(t, js, subject, dto.OrderEvent{
+				EventID:          "event-released",
+				OrderID:          "order-1",
+				FieldScheduleIDs: []string{"slot-1"},
+			})
+			waitSettled(t, js, subject)
+
+			if got := handler.slot("slot-1"); got != "" {
+				t.Errorf("expected slot-1 to be available, got order %q", got)
+			}
+		})
+	}
+}
+
+func TestOrderSubscriberRedeliversFailedEvent(t *testing.T) {
+	handler := newFakeHandler()
+	handler.failWith("event-1", errTransient)
+	js := startSubscriber(t, handler)
+
+	publish(t, js, testSubjects.Paid, dto.OrderEvent{
+		EventID:          "event-1",
+		OrderID:          "order-1",
+		FieldScheduleIDs: []string{"slot-1"},
+	})
+	waitSettled(t, js, testSubjects.Paid)
+
+	if got := handler.slot("slot-1"); got != "order-1" {
+		t.Errorf("expected the redelivered event to book slot-1, got %q", got)
+	}
+	if calls := handler.callCount("event-1"); calls != 2 {
+		t.Errorf("expected 2 deliveries, got %d", calls)
+	}
+}
+
+func TestOrderSubscriberSkipsDuplicateEvent(t *testing.T) {
+	handler := newFakeHandler()
+	js := startSubscriber(t, handler)
+
+	event := dto.OrderEvent{
+		EventID:          "event-1",
+		OrderID:          "order-1",
+		FieldScheduleIDs: []string{"slot-1"},
+	}
+	publish(t, js, testSubjects.Paid, event)
+	waitSettled(t, js, testSubjects.Paid)
+
+	// A duplicate of an applied event must not overwrite later changes
+	publish(t, js, testSubjects.Cancelled, dto.OrderEvent{
+		EventID:          "event-2",
+		OrderID:          "order-1",
+		FieldScheduleIDs: []string{"slot-1"},
+	})
+	waitSettled(t, js, testSubjects.Cancelled)
+	publish(t, js, testSubjects.Paid, event)
+	waitSettled(t, js, testSubjects.Paid)
+
+	if calls := handler.callCount("event-1"); calls != 2 {
+		t.Errorf("expected the duplicate to be delivered, got %d deliveries", calls)
+	}
+	if got := handler.slot("slot-1"); got != "" {
+		t.Errorf("expected the duplicate to be skipped, slot-1 is booked by %q", got)
+	}
+}
+
+func TestOrderSubscriberTerminatesPermanentErrors(t *testing.T) {
+	handler := newFakeHandler()
+	handler.failWith("event-1", errFieldSchedule.ErrFieldScheduleIsBooked, errTransient)
+	js := startSubscriber(t, handler)
+
+	publish(t, js, testSubjects.Paid, dto.OrderEvent{
+		EventID:          "event-1",
+		OrderID:          "order-1",
+		FieldScheduleIDs: []string{"slot-1"},
+	})
+	waitSettled(t, js, testSubjects.Paid)
+
+	if calls := handler.callCount("event-1"); calls != 1 {
+		t.Errorf("expected a single delivery, got %d", calls)
+	}
+}